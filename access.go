@@ -0,0 +1,128 @@
+package ccv2
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// maxQueryValues is the maximum number of values sent in a single IN query,
+// which keeps request URLs within the limits of common proxies.
+const maxQueryValues = 50
+
+// AccessReport describes the access a user has to a foundation.
+type AccessReport struct {
+	User User
+	// Roles are the organization roles of the user followed by the space
+	// roles of the user.
+	Roles []RoleAssignment
+	// Applications are the applications in the spaces in which the user
+	// holds any role.
+	Applications []Application
+	// Events are the events in which the user was the actor.
+	Events []Event
+}
+
+// UserAccess reports the roles held by the user with the given username or
+// GUID, the applications in the spaces in which the user holds a role and
+// the events in which the user was the actor between since and until. A zero
+// since or until leaves the respective end of the window open.
+//
+// Resolving a username requires listing all users, which is permitted only
+// to admins.
+func (c *Client) UserAccess(ctx context.Context, user string, since, until time.Time) (AccessReport, error) {
+	u, err := c.findUser(ctx, user)
+	if err != nil {
+		return AccessReport{}, err
+	}
+	report := AccessReport{User: u}
+
+	orgs := make(map[string]Organization)
+	for _, role := range organizationRoles {
+		path, err := role.userCollection(u.GUID)
+		if err != nil {
+			return AccessReport{}, err
+		}
+		roleOrgs, err := ListResources[OrganizationEntity](ctx, c, path)
+		if err != nil {
+			return AccessReport{}, errors.Wrapf(err, "listing %s organizations failed", role)
+		}
+		for _, org := range roleOrgs {
+			orgs[org.GUID] = org
+			report.Roles = append(report.Roles, RoleAssignment{User: u, Role: role, Organization: org})
+		}
+	}
+
+	var spaceGUIDs []string
+	seen := make(map[string]bool)
+	for _, role := range spaceRoles {
+		path, err := role.userCollection(u.GUID)
+		if err != nil {
+			return AccessReport{}, err
+		}
+		spaces, err := ListResources[SpaceEntity](ctx, c, path)
+		if err != nil {
+			return AccessReport{}, errors.Wrapf(err, "listing %s spaces failed", role)
+		}
+		for _, space := range spaces {
+			org, ok := orgs[space.Entity.OrganizationGUID]
+			if !ok {
+				org, err = c.Organization(ctx, space.Entity.OrganizationGUID)
+				if err != nil {
+					return AccessReport{}, errors.Wrap(err, "fetching space organization failed")
+				}
+				orgs[org.GUID] = org
+			}
+			report.Roles = append(report.Roles, RoleAssignment{User: u, Role: role, Organization: org, Space: space})
+			if !seen[space.GUID] {
+				seen[space.GUID] = true
+				spaceGUIDs = append(spaceGUIDs, space.GUID)
+			}
+		}
+	}
+
+	for len(spaceGUIDs) > 0 {
+		n := min(len(spaceGUIDs), maxQueryValues)
+		apps, err := c.Applications(ctx, In(FilterSpaceGUID, spaceGUIDs[:n]...))
+		if err != nil {
+			return AccessReport{}, errors.Wrap(err, "listing applications failed")
+		}
+		report.Applications = append(report.Applications, apps...)
+		spaceGUIDs = spaceGUIDs[n:]
+	}
+
+	queries := []Query{Eq(FilterActor, u.GUID)}
+	if !since.IsZero() {
+		queries = append(queries, Ge(FilterTimestamp, since.UTC().Format(time.RFC3339)))
+	}
+	if !until.IsZero() {
+		queries = append(queries, Le(FilterTimestamp, until.UTC().Format(time.RFC3339)))
+	}
+	report.Events, err = c.Events(ctx, queries...)
+	if err != nil {
+		return AccessReport{}, errors.Wrap(err, "listing events failed")
+	}
+	return report, nil
+}
+
+// findUser returns the user with the given GUID or, if there is no such
+// user, the user with the given username.
+func (c *Client) findUser(ctx context.Context, user string) (User, error) {
+	u, err := c.User(ctx, user)
+	if err == nil {
+		return u, nil
+	}
+	if !errors.Is(err, ErrNotFound) {
+		return User{}, errors.Wrap(err, "fetching user failed")
+	}
+	for u, err := range c.EachUser(ctx) {
+		if err != nil {
+			return User{}, errors.Wrap(err, "listing users failed")
+		}
+		if u.Entity.Username == user {
+			return u, nil
+		}
+	}
+	return User{}, errors.Wrapf(ErrNotFound, "user %q", user)
+}
@@ -0,0 +1,169 @@
+package ccv2_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	. "github.com/Bo0mer/ccv2"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("UserAccess", func() {
+	var client *Client
+	var server *ghttp.Server
+
+	var user string
+	var report AccessReport
+	var err error
+
+	const orgGUID = "d154425c-dccc-42e6-b6b4-27d46c3b42cb"
+	const spaceGUID = "1053174d-eb79-4f16-bf82-9f83a52d6e84"
+
+	emptyResponse := ghttp.RespondWith(http.StatusOK, `{"next_url": null, "resources": []}`)
+
+	BeforeEach(func() {
+		client, server = setupTestClientAndServer()
+		user = "alice-guid"
+
+		server.RouteToHandler("GET", "/v2/users/alice-guid", ghttp.RespondWith(http.StatusOK, `
+{
+    "metadata": {"guid": "alice-guid"},
+    "entity": {"username": "alice", "active": true}
+}`))
+		server.RouteToHandler("GET", "/v2/users/alice-guid/managed_organizations", emptyResponse)
+		server.RouteToHandler("GET", "/v2/users/alice-guid/billing_managed_organizations", emptyResponse)
+		server.RouteToHandler("GET", "/v2/users/alice-guid/audited_organizations", emptyResponse)
+		server.RouteToHandler("GET", "/v2/users/alice-guid/organizations", ghttp.RespondWith(http.StatusOK, fmt.Sprintf(`
+{
+    "next_url": null,
+    "resources": [
+        {"metadata": {"guid": %q}, "entity": {"name": "acme"}}
+    ]
+}`, orgGUID)))
+		spacesResponse := ghttp.RespondWith(http.StatusOK, fmt.Sprintf(`
+{
+    "next_url": null,
+    "resources": [
+        {"metadata": {"guid": %q}, "entity": {"name": "dev", "organization_guid": %q}}
+    ]
+}`, spaceGUID, orgGUID))
+		server.RouteToHandler("GET", "/v2/users/alice-guid/managed_spaces", emptyResponse)
+		server.RouteToHandler("GET", "/v2/users/alice-guid/spaces", spacesResponse)
+		server.RouteToHandler("GET", "/v2/users/alice-guid/audited_spaces", spacesResponse)
+		server.RouteToHandler("GET", "/v2/apps", ghttp.CombineHandlers(
+			ghttp.VerifyRequest("GET", "/v2/apps", "q=space_guid+IN+"+spaceGUID),
+			ghttp.RespondWith(http.StatusOK, `
+{
+    "next_url": null,
+    "resources": [
+        {"metadata": {"guid": "cd897c8c-3171-456d-b5d7-3c87feeabbd1"}, "entity": {"name": "orders"}}
+    ]
+}`),
+		))
+		server.RouteToHandler("GET", "/v2/events", ghttp.CombineHandlers(
+			ghttp.VerifyRequest("GET", "/v2/events",
+				"q=actor%3Aalice-guid&q=timestamp%3E%3D2016-06-01T00%3A00%3A00Z&q=timestamp%3C%3D2016-07-01T00%3A00%3A00Z"),
+			ghttp.RespondWith(http.StatusOK, `
+{
+    "next_url": null,
+    "resources": [
+        {
+            "metadata": {"guid": "b8ede8e1-afc8-40a1-baae-236a0a77b27b"},
+            "entity": {"type": "audit.app.update", "actor": "alice-guid", "actee_name": "orders"}
+        }
+    ]
+}`),
+		))
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	JustBeforeEach(func() {
+		since := parseTime("2016-06-01T00:00:00Z")
+		until := parseTime("2016-07-01T00:00:00Z")
+		report, err = client.UserAccess(context.Background(), user, since, until)
+	})
+
+	It("should have returned the roles of the user", func() {
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(report.User.GUID).Should(Equal("alice-guid"))
+
+		type row struct {
+			role       Role
+			org, space string
+		}
+		rows := make([]row, len(report.Roles))
+		for i, a := range report.Roles {
+			Ω(a.User.GUID).Should(Equal("alice-guid"))
+			rows[i] = row{a.Role, a.Organization.Entity.Name, a.Space.Entity.Name}
+		}
+		Ω(rows).Should(Equal([]row{
+			{RoleOrganizationUser, "acme", ""},
+			{RoleSpaceDeveloper, "acme", "dev"},
+			{RoleSpaceAuditor, "acme", "dev"},
+		}))
+	})
+
+	It("should have returned the applications in the spaces of the user", func() {
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(report.Applications).Should(HaveLen(1))
+		Ω(report.Applications[0].Entity.Name).Should(Equal("orders"))
+	})
+
+	It("should have returned the events of the user in the window", func() {
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(report.Events).Should(HaveLen(1))
+		Ω(report.Events[0].Entity.Type).Should(Equal("audit.app.update"))
+	})
+
+	Context("when a username is provided", func() {
+		BeforeEach(func() {
+			user = "alice"
+			server.RouteToHandler("GET", "/v2/users/alice", notFoundHandler())
+			server.RouteToHandler("GET", "/v2/users", ghttp.RespondWith(http.StatusOK, `
+{
+    "next_url": null,
+    "resources": [
+        {"metadata": {"guid": "bob-guid"}, "entity": {"username": "bob"}},
+        {"metadata": {"guid": "alice-guid"}, "entity": {"username": "alice"}}
+    ]
+}`))
+		})
+
+		It("should have resolved the user by username", func() {
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(report.User.GUID).Should(Equal("alice-guid"))
+			Ω(report.Roles).Should(HaveLen(3))
+		})
+	})
+
+	Context("when the user does not exist", func() {
+		BeforeEach(func() {
+			user = "mallory"
+			server.RouteToHandler("GET", "/v2/users/mallory", notFoundHandler())
+			server.RouteToHandler("GET", "/v2/users", emptyResponse)
+		})
+
+		It("should have returned a not found error", func() {
+			Ω(err).Should(MatchError(ErrNotFound))
+			Ω(err).Should(MatchError(ContainSubstring("mallory")))
+		})
+	})
+
+	Context("when fetching the user fails", func() {
+		BeforeEach(func() {
+			server.RouteToHandler("GET", "/v2/users/alice-guid", ghttp.RespondWith(http.StatusForbidden, `{}`))
+		})
+
+		It("should have returned the error", func() {
+			Ω(err).Should(MatchError(ErrForbidden))
+			Ω(report).Should(BeZero())
+		})
+	})
+})
@@ -1,49 +1,200 @@
 package ccv2
 
+import (
+	"context"
+	"encoding/json"
+	"iter"
+	"net/http"
+	"net/url"
+	"reflect"
+	"time"
+)
+
+// AppState is the desired state of an application. Values not known to
+// this package are preserved as returned by the Cloud Controller.
+type AppState string
+
+const (
+	// AppStateStarted means that the application should be running.
+	AppStateStarted AppState = "STARTED"
+	// AppStateStopped means that the application should not be running.
+	AppStateStopped AppState = "STOPPED"
+)
+
+// IsRunning reports whether the application is meant to be running.
+func (s AppState) IsRunning() bool {
+	return s == AppStateStarted
+}
+
+// IsStopped reports whether the application is meant to be stopped.
+func (s AppState) IsStopped() bool {
+	return s == AppStateStopped
+}
+
+// PackageState is the staging state of the package of an application.
+// Values not known to this package are preserved as returned by the Cloud
+// Controller.
+type PackageState string
+
+const (
+	// PackageStatePending means that the package has not been staged yet.
+	PackageStatePending PackageState = "PENDING"
+	// PackageStateStaged means that the package has been staged successfully.
+	PackageStateStaged PackageState = "STAGED"
+	// PackageStateFailed means that staging the package has failed.
+	PackageStateFailed PackageState = "FAILED"
+)
+
+// IsPending reports whether the package is waiting to be staged.
+func (s PackageState) IsPending() bool {
+	return s == PackageStatePending
+}
+
+// IsStaged reports whether the package has been staged successfully.
+func (s PackageState) IsStaged() bool {
+	return s == PackageStateStaged
+}
+
+// IsFailed reports whether staging the package has failed.
+func (s PackageState) IsFailed() bool {
+	return s == PackageStateFailed
+}
+
+// HealthCheckType is the type of health check performed on the instances of
+// an application. Values not known to this package are preserved as
+// returned by the Cloud Controller.
+type HealthCheckType string
+
+const (
+	// HealthCheckPort checks whether the instance accepts TCP connections.
+	HealthCheckPort HealthCheckType = "port"
+	// HealthCheckProcess checks whether the process of the instance is alive.
+	HealthCheckProcess HealthCheckType = "process"
+	// HealthCheckHTTP checks whether the instance responds to HTTP requests.
+	HealthCheckHTTP HealthCheckType = "http"
+	// HealthCheckNone is a deprecated synonym of HealthCheckProcess.
+	HealthCheckNone HealthCheckType = "none"
+)
+
 // Application represents a Cloud Foundry application.
-type Application struct {
-	Metadata `json:"metadata"`
-
-	Entity struct {
-		Name               string `json:"name"`
-		SpaceGUID          string `json:"space_guid"`
-		StackGUID          string `json:"stack_guid"`
-		Memory             int    `json:"memory"`
-		Instances          int    `json:"instances"`
-		DiskQuota          int    `json:"disk_quota"`
-		State              string `json:"state"`
-		Version            string `json:"version"`
-		PackageState       string `json:"package_state"`
-		HealthCheckType    string `json:"health_check_type"`
-		HealthCheckTimeout int    `json:"health_check_timeout"`
-		Buildpack          string `json:"buildpack"`
-		Command            string `json:"command"`
-		DetectedBuildpack  string `json:"detected_buildpack"`
-		DetectedCommand    string `json:"detected_start_command"`
-		Diego              bool   `json:"diego"`
-		EnableSSH          bool   `json:"enable_ssh"`
-	} `json:"entity"`
+type Application = Resource[ApplicationEntity]
+
+// ApplicationEntity holds the fields of an application.
+type ApplicationEntity struct {
+	Name               string          `json:"name"`
+	SpaceGUID          string          `json:"space_guid"`
+	StackGUID          string          `json:"stack_guid"`
+	Memory             int             `json:"memory"`
+	Instances          int             `json:"instances"`
+	DiskQuota          int             `json:"disk_quota"`
+	State              AppState        `json:"state"`
+	Version            string          `json:"version"`
+	PackageState       PackageState    `json:"package_state"`
+	HealthCheckType    HealthCheckType `json:"health_check_type"`
+	HealthCheckTimeout int             `json:"health_check_timeout"`
+	Buildpack          string          `json:"buildpack"`
+	Command            string          `json:"command"`
+	DetectedBuildpack  string          `json:"detected_buildpack"`
+	DetectedCommand    string          `json:"detected_start_command"`
+	Diego              bool            `json:"diego"`
+	EnableSSH          bool            `json:"enable_ssh"`
+
+	Ports                    []int                  `json:"ports"`
+	DockerImage              string                 `json:"docker_image"`
+	EnvironmentJSON          map[string]interface{} `json:"environment_json"`
+	StagingFailedReason      string                 `json:"staging_failed_reason"`
+	StagingFailedDescription string                 `json:"staging_failed_description"`
+	DetectedBuildpackGUID    string                 `json:"detected_buildpack_guid"`
+	// PackageUpdatedAt is the zero time if the package has never been
+	// uploaded.
+	PackageUpdatedAt time.Time `json:"package_updated_at"`
+}
+
+// Applications list all applications that conform to the provided queries.
+func (c *Client) Applications(ctx context.Context, queries ...Query) ([]Application, error) {
+	return ListResources[ApplicationEntity](ctx, c, "/v2/apps", queries...)
+}
+
+// EachApplication returns an iterator over all applications that conform to the
+// provided queries. Unlike Applications, it holds at most one page in memory
+// and stops requesting pages as soon as the loop is exited.
+func (c *Client) EachApplication(ctx context.Context, queries ...Query) iter.Seq2[Application, error] {
+	return EachResource[ApplicationEntity](ctx, c, "/v2/apps", queries...)
+}
+
+// Application returns the application with the given GUID.
+func (c *Client) Application(ctx context.Context, guid string) (Application, error) {
+	return GetResource[ApplicationEntity](ctx, c, "/v2/apps", guid)
 }
 
 // ApplicationSummary represents summary about an application.
 type ApplicationSummary struct {
-	GUID               string `json:"guid"`
-	Name               string `json:"name"`
-	SpaceGUID          string `json:"space_guid"`
-	StackGUID          string `json:"stack_guid"`
-	Memory             int    `json:"memory"`
-	Instances          int    `json:"instances"`
-	DiskQuota          int    `json:"disk_quota"`
-	State              string `json:"state"`
-	Version            string `json:"version"`
-	PackageState       string `json:"package_state"`
-	HealthCheckType    string `json:"health_check_type"`
-	HealthCheckTimeout int    `json:"health_check_timeout"`
-	Buildpack          string `json:"buildpack"`
-	Command            string `json:"command"`
-	DetectedBuildpack  string `json:"detected_buildpack"`
-	DetectedCommand    string `json:"detected_start_command"`
-	Diego              bool   `json:"diego"`
-	EnableSSH          bool   `json:"enable_ssh"`
-	RunningInstances   int    `json:"running_instances"`
+	GUID               string          `json:"guid"`
+	Name               string          `json:"name"`
+	SpaceGUID          string          `json:"space_guid"`
+	StackGUID          string          `json:"stack_guid"`
+	Memory             int             `json:"memory"`
+	Instances          int             `json:"instances"`
+	DiskQuota          int             `json:"disk_quota"`
+	State              AppState        `json:"state"`
+	Version            string          `json:"version"`
+	PackageState       PackageState    `json:"package_state"`
+	HealthCheckType    HealthCheckType `json:"health_check_type"`
+	HealthCheckTimeout int             `json:"health_check_timeout"`
+	Buildpack          string          `json:"buildpack"`
+	Command            string          `json:"command"`
+	DetectedBuildpack  string          `json:"detected_buildpack"`
+	DetectedCommand    string          `json:"detected_start_command"`
+	Diego              bool            `json:"diego"`
+	EnableSSH          bool            `json:"enable_ssh"`
+	RunningInstances   int             `json:"running_instances"`
+
+	Ports                    []int                  `json:"ports"`
+	DockerImage              string                 `json:"docker_image"`
+	EnvironmentJSON          map[string]interface{} `json:"environment_json"`
+	StagingFailedReason      string                 `json:"staging_failed_reason"`
+	StagingFailedDescription string                 `json:"staging_failed_description"`
+	DetectedBuildpackGUID    string                 `json:"detected_buildpack_guid"`
+	PackageUpdatedAt         time.Time              `json:"package_updated_at"`
+
+	Routes   []RouteSummary           `json:"routes"`
+	Services []ServiceInstanceSummary `json:"services"`
+
+	// Raw is the summary as returned by the Cloud Controller.
+	Raw json.RawMessage `json:"-"`
+	// Extra holds the summary fields that are not declared above, keyed by
+	// their JSON name.
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// UnmarshalJSON decodes the summary, keeping the raw summary and the fields
+// unknown to ApplicationSummary.
+func (s *ApplicationSummary) UnmarshalJSON(data []byte) error {
+	type summary ApplicationSummary
+	var decoded summary
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+	extra, err := unknownFields(data, reflect.TypeOf(decoded))
+	if err != nil {
+		return err
+	}
+	*s = ApplicationSummary(decoded)
+	s.Raw = append(json.RawMessage(nil), data...)
+	s.Extra = extra
+	return nil
+}
+
+// ApplicationSummary returns summary for a given application.
+func (c *Client) ApplicationSummary(ctx context.Context, app Application) (ApplicationSummary, error) {
+	var summary ApplicationSummary
+	err := c.get(requestOpts{
+		Context: ctx,
+		Method:  http.MethodGet,
+		Path:    "/v2/apps/" + url.PathEscape(app.GUID) + "/summary",
+	}, &summary)
+	if err != nil {
+		return ApplicationSummary{}, err
+	}
+	return summary, nil
 }
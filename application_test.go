@@ -2,9 +2,12 @@ package ccv2_test
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
 
+	"github.com/pkg/errors"
+
 	. "github.com/Bo0mer/ccv2"
 
 	. "github.com/onsi/ginkgo"
@@ -49,8 +52,13 @@ var _ = Describe("Application", func() {
 				"command": "command",
 				"detected_buildpack": "detected_buildpack",
                 "detected_start_command": "detected_start_command",
+                "detected_buildpack_guid": "detected_buildpack_guid",
 				"diego": true,
+                "docker_image": null,
                 "disk_quota": 1024,
+                "environment_json": {"LOG_LEVEL": "debug", "WORKERS": 4},
+                "ports": [8080, 9090],
+                "production": false,
                 "enable_ssh": true,
                 "health_check_timeout": 30,
                 "health_check_type": "port",
@@ -83,18 +91,18 @@ var _ = Describe("Application", func() {
 				Ω(applications).Should(HaveLen(1))
 				app := applications[0]
 				Ω(app.GUID).Should(Equal("6064d98a-95e6-400b-bc03-be65e6d59622"))
-				Ω(app.CreatedAt).Should(Equal("2016-06-08T16:41:45Z"))
-				Ω(app.UpdatedAt).Should(Equal("2016-06-08T16:41:45Z"))
+				Ω(app.CreatedAt).Should(Equal(parseTime("2016-06-08T16:41:45Z")))
+				Ω(app.UpdatedAt).Should(Equal(parseTime("2016-06-08T16:41:45Z")))
 				Ω(app.Entity.Name).Should(Equal("name-2443"))
 				Ω(app.Entity.SpaceGUID).Should(Equal("9c5c8a91-a728-4608-9f5e-6c8026c3a2ac"))
 				Ω(app.Entity.StackGUID).Should(Equal("f6c960cc-98ba-4fd1-b197-ecbf39108aa2"))
 				Ω(app.Entity.Memory).Should(Equal(1024))
 				Ω(app.Entity.Instances).Should(Equal(1))
 				Ω(app.Entity.DiskQuota).Should(Equal(1024))
-				Ω(app.Entity.State).Should(Equal("STOPPED"))
+				Ω(app.Entity.State).Should(Equal(AppStateStopped))
 				Ω(app.Entity.Version).Should(Equal("f5696e0f-087d-49b0-9ad7-4756c49a6ba6"))
-				Ω(app.Entity.PackageState).Should(Equal("PENDING"))
-				Ω(app.Entity.HealthCheckType).Should(Equal("port"))
+				Ω(app.Entity.PackageState).Should(Equal(PackageStatePending))
+				Ω(app.Entity.HealthCheckType).Should(Equal(HealthCheckPort))
 				Ω(app.Entity.HealthCheckTimeout).Should(Equal(30))
 				Ω(app.Entity.Buildpack).Should(Equal("buildpack"))
 				Ω(app.Entity.Command).Should(Equal("command"))
@@ -102,6 +110,23 @@ var _ = Describe("Application", func() {
 				Ω(app.Entity.DetectedCommand).Should(Equal("detected_start_command"))
 				Ω(app.Entity.Diego).Should(BeTrue())
 				Ω(app.Entity.EnableSSH).Should(BeTrue())
+				Ω(app.Entity.DetectedBuildpackGUID).Should(Equal("detected_buildpack_guid"))
+				Ω(app.Entity.DockerImage).Should(BeEmpty())
+				Ω(app.Entity.EnvironmentJSON).Should(Equal(map[string]interface{}{
+					"LOG_LEVEL": "debug",
+					"WORKERS":   float64(4),
+				}))
+				Ω(app.Entity.Ports).Should(Equal([]int{8080, 9090}))
+				Ω(app.Entity.StagingFailedReason).Should(BeEmpty())
+				Ω(app.Entity.PackageUpdatedAt).Should(Equal(parseTime("2016-06-08T16:41:45Z")))
+			})
+
+			It("should have preserved the undeclared entity fields", func() {
+				app := applications[0]
+				Ω(app.RawEntity).ShouldNot(BeEmpty())
+				Ω(app.Extra).Should(HaveLen(2))
+				Ω(app.Extra).Should(HaveKeyWithValue("production", json.RawMessage("false")))
+				Ω(app.Extra).Should(HaveKeyWithValue("staging_task_id", json.RawMessage("null")))
 			})
 
 			It("should have not returned an error", func() {
@@ -115,7 +140,7 @@ var _ = Describe("Application", func() {
 			})
 
 			It("should have returned a UnexpectedResponseError", func() {
-				Ω(err).Should(Equal(notFoundErr))
+				Ω(err).Should(beNotFoundErr())
 			})
 		})
 	})
@@ -153,7 +178,50 @@ var _ = Describe("Application", func() {
     "name": "name-79",
     "package_state": "PENDING",
     "package_updated_at": "2016-06-08T16:41:22Z",
+    "routes": [
+        {
+            "guid": "8b4ec76e-5a3b-4a58-9fe5-8f4e9c8c0a77",
+            "host": "name-79",
+            "port": null,
+            "path": "/api",
+            "domain": {
+                "guid": "4fd3d5d7-3f9f-4bd4-9a5e-5a2d3b5b3c6e",
+                "name": "example.com"
+            }
+        }
+    ],
     "running_instances": 0,
+    "production": false,
+    "services": [
+        {
+            "guid": "b8f3d3a2-2f5e-4b7e-9c1d-0e4a6f7b8c9d",
+            "name": "orders-db",
+            "bound_app_count": 2,
+            "last_operation": {
+                "type": "create",
+                "state": "succeeded",
+                "description": "",
+                "updated_at": null,
+                "created_at": "2016-06-08T16:41:30Z"
+            },
+            "dashboard_url": "https://db.example.com/dashboard",
+            "service_plan": {
+                "guid": "7e6d5c4b-3a29-4180-9f8e-7d6c5b4a3928",
+                "name": "small",
+                "service": {
+                    "guid": "1a2b3c4d-5e6f-4a8b-9c0d-1e2f3a4b5c6d",
+                    "label": "postgres",
+                    "provider": null,
+                    "version": null
+                }
+            }
+        },
+        {
+            "guid": "e2d1c0b9-a8f7-4e6d-8c5b-4a3928170615",
+            "name": "log-drain",
+            "bound_app_count": 1
+        }
+    ],
     "space_guid": "1053174d-eb79-4f16-bf82-9f83a52d6e84",
     "stack_guid": "aff73b55-7767-4928-b0ce-502cca863be0",
     "state": "STOPPED",
@@ -174,10 +242,10 @@ var _ = Describe("Application", func() {
 				Ω(summary.Memory).Should(Equal(1024))
 				Ω(summary.Instances).Should(Equal(1))
 				Ω(summary.DiskQuota).Should(Equal(1024))
-				Ω(summary.State).Should(Equal("STOPPED"))
+				Ω(summary.State).Should(Equal(AppStateStopped))
 				Ω(summary.Version).Should(Equal("d457b51a-d7cb-494d-b39e-3171ec75bd60"))
-				Ω(summary.PackageState).Should(Equal("PENDING"))
-				Ω(summary.HealthCheckType).Should(Equal("port"))
+				Ω(summary.PackageState).Should(Equal(PackageStatePending))
+				Ω(summary.HealthCheckType).Should(Equal(HealthCheckPort))
 				Ω(summary.HealthCheckTimeout).Should(Equal(30))
 				Ω(summary.Buildpack).Should(Equal("buildpack"))
 				Ω(summary.Command).Should(Equal("command"))
@@ -186,6 +254,46 @@ var _ = Describe("Application", func() {
 				Ω(summary.Diego).Should(BeTrue())
 				Ω(summary.EnableSSH).Should(BeTrue())
 				Ω(summary.RunningInstances).Should(Equal(0))
+				Ω(summary.Routes).Should(Equal([]RouteSummary{
+					{
+						GUID: "8b4ec76e-5a3b-4a58-9fe5-8f4e9c8c0a77",
+						Host: "name-79",
+						Path: "/api",
+						Domain: DomainSummary{
+							GUID: "4fd3d5d7-3f9f-4bd4-9a5e-5a2d3b5b3c6e",
+							Name: "example.com",
+						},
+					},
+				}))
+				Ω(summary.Services).Should(HaveLen(2))
+				Ω(summary.Services[0]).Should(Equal(ServiceInstanceSummary{
+					GUID:          "b8f3d3a2-2f5e-4b7e-9c1d-0e4a6f7b8c9d",
+					Name:          "orders-db",
+					BoundAppCount: 2,
+					DashboardURL:  "https://db.example.com/dashboard",
+					LastOperation: LastOperation{
+						Type:      "create",
+						State:     "succeeded",
+						CreatedAt: parseTime("2016-06-08T16:41:30Z"),
+					},
+					ServicePlan: ServicePlanSummary{
+						GUID: "7e6d5c4b-3a29-4180-9f8e-7d6c5b4a3928",
+						Name: "small",
+						Service: ServiceSummary{
+							GUID:  "1a2b3c4d-5e6f-4a8b-9c0d-1e2f3a4b5c6d",
+							Label: "postgres",
+						},
+					},
+				}))
+				Ω(summary.Services[0].IsUserProvided()).Should(BeFalse())
+				Ω(summary.Services[1].IsUserProvided()).Should(BeTrue())
+			})
+
+			It("should have kept the raw summary and the unknown fields", func() {
+				Ω(summary.Raw).Should(ContainSubstring(`"name": "name-79"`))
+				Ω(summary.Extra).Should(Equal(map[string]json.RawMessage{
+					"production": json.RawMessage("false"),
+				}))
 			})
 		})
 
@@ -195,8 +303,82 @@ var _ = Describe("Application", func() {
 			})
 
 			It("should have returned a UnexpectedResponseError", func() {
-				Ω(err).Should(Equal(notFoundErr))
+				Ω(err).Should(beNotFoundErr())
 			})
 		})
 	})
 })
+
+var _ = Describe("Application", func() {
+	var client *Client
+	var server *ghttp.Server
+
+	var app Application
+	var err error
+
+	BeforeEach(func() {
+		client, server = setupTestClientAndServer()
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	JustBeforeEach(func() {
+		app, err = client.Application(context.Background(), "6064d98a-95e6-400b-bc03-be65e6d59622")
+	})
+
+	Context("when the server returns a valid response", func() {
+		BeforeEach(func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/v2/apps/6064d98a-95e6-400b-bc03-be65e6d59622"),
+					ghttp.RespondWith(http.StatusOK, `
+{
+    "metadata": {
+        "guid": "6064d98a-95e6-400b-bc03-be65e6d59622"
+    },
+    "entity": {
+        "name": "name-2443"
+    }
+}`),
+				),
+			)
+		})
+
+		It("should have returned the application", func() {
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(app.GUID).Should(Equal("6064d98a-95e6-400b-bc03-be65e6d59622"))
+			Ω(app.Entity.Name).Should(Equal("name-2443"))
+		})
+	})
+
+	Context("when the application does not exist", func() {
+		BeforeEach(func() {
+			server.AppendHandlers(notFoundHandler())
+		})
+
+		It("should have returned an error matching ErrNotFound", func() {
+			Ω(err).Should(beNotFoundErr())
+			Ω(errors.Is(err, ErrNotFound)).Should(BeTrue())
+		})
+	})
+})
+
+var _ = Describe("AppState", func() {
+	It("should report whether the application is running", func() {
+		Ω(AppStateStarted.IsRunning()).Should(BeTrue())
+		Ω(AppStateStopped.IsRunning()).Should(BeFalse())
+		Ω(AppState("SUSPENDED").IsRunning()).Should(BeFalse())
+		Ω(AppStateStopped.IsStopped()).Should(BeTrue())
+	})
+})
+
+var _ = Describe("PackageState", func() {
+	It("should report the staging state", func() {
+		Ω(PackageStateStaged.IsStaged()).Should(BeTrue())
+		Ω(PackageStatePending.IsPending()).Should(BeTrue())
+		Ω(PackageStateFailed.IsFailed()).Should(BeTrue())
+		Ω(PackageState("UNKNOWN").IsStaged()).Should(BeFalse())
+	})
+})
@@ -0,0 +1,126 @@
+package auth
+
+import (
+	"crypto/tls"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/Bo0mer/ccv2"
+	"github.com/pkg/errors"
+	"golang.org/x/oauth2"
+)
+
+// CFConfig represents the parts of the cf CLI configuration file that are
+// needed to talk to the targeted Cloud Controller.
+type CFConfig struct {
+	Target                string `json:"Target"`
+	AuthorizationEndpoint string `json:"AuthorizationEndpoint"`
+	UaaEndpoint           string `json:"UaaEndpoint"`
+	AccessToken           string `json:"AccessToken"`
+	RefreshToken          string `json:"RefreshToken"`
+	UAAOAuthClient        string `json:"UAAOAuthClient"`
+	UAAOAuthClientSecret  string `json:"UAAOAuthClientSecret"`
+	SSLDisabled           bool   `json:"SSLDisabled"`
+}
+
+// CFConfigPath returns the path of the cf CLI configuration file. Just like
+// the cf CLI, it honours the CF_HOME environment variable and otherwise
+// defaults to the home directory of the current user.
+func CFConfigPath() (string, error) {
+	home := os.Getenv("CF_HOME")
+	if home == "" {
+		var err error
+		home, err = os.UserHomeDir()
+		if err != nil {
+			return "", errors.Wrap(err, "determining home directory failed")
+		}
+	}
+	return filepath.Join(home, ".cf", "config.json"), nil
+}
+
+// LoadCFConfig reads the cf CLI configuration file from CFConfigPath.
+func LoadCFConfig() (*CFConfig, error) {
+	path, err := CFConfigPath()
+	if err != nil {
+		return nil, err
+	}
+	return ReadCFConfig(path)
+}
+
+// ReadCFConfig reads the cf CLI configuration file at path.
+func ReadCFConfig(path string) (*CFConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "reading cf config failed")
+	}
+	var cfg CFConfig
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return nil, errors.Wrapf(err, "parsing cf config %q failed", path)
+	}
+	return &cfg, nil
+}
+
+// UAA returns the UAA that issued the tokens in the configuration.
+func (cfg *CFConfig) UAA() *UAA {
+	clientID := cfg.UAAOAuthClient
+	if clientID == "" {
+		clientID = DefaultClientID
+	}
+	tokenEndpoint := cfg.UaaEndpoint
+	if tokenEndpoint == "" {
+		tokenEndpoint = cfg.AuthorizationEndpoint
+	}
+	uaa := &UAA{
+		AuthorizationEndpoint: cfg.AuthorizationEndpoint,
+		TokenEndpoint:         tokenEndpoint,
+		ClientID:              clientID,
+		ClientSecret:          cfg.UAAOAuthClientSecret,
+	}
+	if cfg.SSLDisabled {
+		uaa.HTTPClient = &http.Client{
+			Transport: &http.Transport{
+				Proxy:           http.ProxyFromEnvironment,
+				TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
+			},
+		}
+	}
+	return uaa
+}
+
+// Client returns a Client for the targeted Cloud Controller, authenticated
+// with the tokens of the logged in user. The access token is refreshed
+// using the refresh token when it expires.
+func (cfg *CFConfig) Client() (*ccv2.Client, error) {
+	if cfg.Target == "" {
+		return nil, errors.New("cf config does not specify target")
+	}
+	if cfg.AccessToken == "" && cfg.RefreshToken == "" {
+		return nil, errors.New("cf config does not specify tokens, log in using cf login")
+	}
+	api, err := url.Parse(cfg.Target)
+	if err != nil {
+		return nil, errors.Wrap(err, "parsing target failed")
+	}
+
+	accessToken := cfg.AccessToken
+	if i := strings.IndexByte(accessToken, ' '); i >= 0 {
+		// The cf CLI stores the token type along with the token.
+		accessToken = accessToken[i+1:]
+	}
+	token := &oauth2.Token{
+		AccessToken:  accessToken,
+		TokenType:    "bearer",
+		RefreshToken: cfg.RefreshToken,
+	}
+	if identity, err := ccv2.ParseToken(accessToken); err == nil {
+		token.Expiry = identity.Expiry
+	}
+	return &ccv2.Client{
+		API:        api,
+		HTTPClient: cfg.UAA().Doer(token),
+	}, nil
+}
@@ -0,0 +1,127 @@
+package auth_test
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/Bo0mer/ccv2/auth"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+// fakeJWT returns an unsigned JWT with the provided claims.
+func fakeJWT(claims string) string {
+	enc := base64.RawURLEncoding
+	return enc.EncodeToString([]byte(`{"alg":"none"}`)) + "." +
+		enc.EncodeToString([]byte(claims)) + ".signature"
+}
+
+var _ = Describe("CFConfig", func() {
+	var server *ghttp.Server
+	var cfHome string
+	var accessToken string
+
+	BeforeEach(func() {
+		server = ghttp.NewServer()
+
+		var err error
+		cfHome, err = os.MkdirTemp("", "cf-home")
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(os.Mkdir(filepath.Join(cfHome, ".cf"), 0700)).Should(Succeed())
+		os.Setenv("CF_HOME", cfHome)
+
+		accessToken = fakeJWT(fmt.Sprintf(`{"exp": %d}`, time.Now().Add(time.Hour).Unix()))
+	})
+
+	JustBeforeEach(func() {
+		config := fmt.Sprintf(`{
+  "ConfigVersion": 3,
+  "Target": %[1]q,
+  "AuthorizationEndpoint": %[1]q,
+  "UaaEndpoint": %[1]q,
+  "AccessToken": "bearer %[2]s",
+  "RefreshToken": "refresh-token",
+  "UAAOAuthClient": "cf",
+  "UAAOAuthClientSecret": "",
+  "SSLDisabled": true
+}`, server.URL(), accessToken)
+		path := filepath.Join(cfHome, ".cf", "config.json")
+		Ω(os.WriteFile(path, []byte(config), 0600)).Should(Succeed())
+	})
+
+	AfterEach(func() {
+		os.Unsetenv("CF_HOME")
+		os.RemoveAll(cfHome)
+		server.Close()
+	})
+
+	It("should have read the configuration from CF_HOME", func() {
+		cfg, err := LoadCFConfig()
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(cfg.Target).Should(Equal(server.URL()))
+		Ω(cfg.AccessToken).Should(Equal("bearer " + accessToken))
+		Ω(cfg.RefreshToken).Should(Equal("refresh-token"))
+		Ω(cfg.SSLDisabled).Should(BeTrue())
+	})
+
+	Context("when the access token is valid", func() {
+		BeforeEach(func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/v2/spaces"),
+					ghttp.VerifyHeaderKV("Authorization", "Bearer "+accessToken),
+					ghttp.RespondWith(http.StatusOK, `{"resources": []}`),
+				),
+			)
+		})
+
+		It("should have built a client using the access token", func() {
+			cfg, err := LoadCFConfig()
+			Ω(err).ShouldNot(HaveOccurred())
+			cf, err := cfg.Client()
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(cf.API.String()).Should(Equal(server.URL()))
+
+			_, err = cf.Spaces(context.Background())
+			Ω(err).ShouldNot(HaveOccurred())
+		})
+	})
+
+	Context("when the access token has expired", func() {
+		BeforeEach(func() {
+			accessToken = fakeJWT(fmt.Sprintf(`{"exp": %d}`, time.Now().Add(-time.Hour).Unix()))
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("POST", "/oauth/token"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, map[string]interface{}{
+						"access_token": "fresh-token",
+						"token_type":   "bearer",
+						"expires_in":   3600,
+					}),
+				),
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/v2/spaces"),
+					ghttp.VerifyHeaderKV("Authorization", "Bearer fresh-token"),
+					ghttp.RespondWith(http.StatusOK, `{"resources": []}`),
+				),
+			)
+		})
+
+		It("should have refreshed the token", func() {
+			cfg, err := LoadCFConfig()
+			Ω(err).ShouldNot(HaveOccurred())
+			cf, err := cfg.Client()
+			Ω(err).ShouldNot(HaveOccurred())
+
+			_, err = cf.Spaces(context.Background())
+			Ω(err).ShouldNot(HaveOccurred())
+		})
+	})
+})
@@ -0,0 +1,25 @@
+// Package auth obtains and maintains UAA tokens for use with package ccv2.
+//
+// The UAA is discovered from the Cloud Controller info endpoint. Tokens can
+// be obtained using the password, client credentials and refresh token
+// grants. The result is a Doer, which authenticates each request it sends
+// and can be used as HTTPClient of a ccv2.Client.
+//
+// Example usage:
+//
+//	cf := &ccv2.Client{
+//	  API:        apiURL,
+//	  HTTPClient: http.DefaultClient,
+//	}
+//
+//	uaa, err := auth.Discover(ctx, cf)
+//	if err != nil {
+//	  log.Fatalf("error discovering uaa: %v\n", err)
+//	}
+//	doer, err := uaa.Password(ctx, "admin", "admin")
+//	if err != nil {
+//	  log.Fatalf("error fetching token: %v\n", err)
+//	}
+//	cf.HTTPClient = doer
+//	// Use cf as authenticated on behalf of admin:admin.
+package auth
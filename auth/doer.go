@@ -0,0 +1,129 @@
+package auth
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"sync"
+
+	"github.com/Bo0mer/ccv2"
+	"github.com/pkg/errors"
+	"golang.org/x/oauth2"
+)
+
+// refreshFunc obtains a new token in place of the provided one.
+type refreshFunc func(ctx context.Context, token *oauth2.Token) (*oauth2.Token, error)
+
+// Doer sends HTTP requests authenticated with a UAA token. The token is
+// refreshed when it expires, or when the Cloud Controller rejects it as
+// invalid, in which case the request is retried once with the new token.
+//
+// Doer is safe for concurrent use and implements ccv2.Doer,
+// ccv2.AccessTokenSource and oauth2.TokenSource.
+type Doer struct {
+	client  *http.Client
+	refresh refreshFunc
+
+	mu    sync.Mutex
+	token *oauth2.Token
+}
+
+// Token returns the current token, refreshing it if it has expired.
+func (d *Doer) Token() (*oauth2.Token, error) {
+	return d.validToken(context.Background(), nil)
+}
+
+// AccessToken returns the current access token, refreshing it if it has
+// expired.
+func (d *Doer) AccessToken() (string, error) {
+	token, err := d.Token()
+	if err != nil {
+		return "", err
+	}
+	return token.AccessToken, nil
+}
+
+// Do sends an authenticated HTTP request and returns the response.
+func (d *Doer) Do(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	token, err := d.validToken(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := d.send(req, token)
+	if err != nil || resp.StatusCode != http.StatusUnauthorized {
+		return resp, err
+	}
+	if req.Body != nil && req.GetBody == nil {
+		return resp, nil
+	}
+
+	invalid, err := isInvalidToken(resp)
+	if err != nil || !invalid {
+		return resp, err
+	}
+	resp.Body.Close()
+
+	token, err = d.validToken(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	return d.send(req, token)
+}
+
+func (d *Doer) send(req *http.Request, token *oauth2.Token) (*http.Response, error) {
+	r := req.Clone(req.Context())
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, errors.Wrap(err, "obtaining request body failed")
+		}
+		r.Body = body
+	}
+	token.SetAuthHeader(r)
+	return d.client.Do(r)
+}
+
+// validToken returns a valid token. If rejected is not nil and is still the
+// current token, it is refreshed regardless of its expiry.
+func (d *Doer) validToken(ctx context.Context, rejected *oauth2.Token) (*oauth2.Token, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.token.Valid() && (rejected == nil || d.token != rejected) {
+		return d.token, nil
+	}
+	token, err := d.refresh(ctx, d.token)
+	if err != nil {
+		return nil, errors.Wrap(err, "refreshing token failed")
+	}
+	d.token = token
+	return token, nil
+}
+
+// isInvalidToken reports whether resp indicates that the Cloud Controller
+// rejected the token of the request. The body of resp is preserved.
+func isInvalidToken(resp *http.Response) (bool, error) {
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 4<<10))
+	if err != nil {
+		resp.Body.Close()
+		return false, errors.Wrap(err, "reading response body failed")
+	}
+	resp.Body = struct {
+		io.Reader
+		io.Closer
+	}{io.MultiReader(bytes.NewReader(body), resp.Body), resp.Body}
+
+	var payload struct {
+		ErrorCode string         `json:"error_code"`
+		Errors    []ccv2.V3Error `json:"errors"`
+	}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return false, nil
+	}
+	if len(payload.Errors) > 0 {
+		payload.ErrorCode = payload.Errors[0].Title
+	}
+	return payload.ErrorCode == ccv2.ErrorCodeInvalidAuthToken, nil
+}
@@ -0,0 +1,158 @@
+package auth
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"golang.org/x/oauth2"
+)
+
+// Prompt represents a piece of information the UAA login server asks for.
+type Prompt struct {
+	// Type is the type of the input, e.g. text or password.
+	Type string
+	// Text is the human readable description of the input.
+	Text string
+}
+
+// UnmarshalJSON decodes a prompt from the [type, text] form used by the UAA.
+func (p *Prompt) UnmarshalJSON(data []byte) error {
+	var fields []string
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+	if len(fields) != 2 {
+		return errors.Errorf("unexpected prompt %s", data)
+	}
+	p.Type, p.Text = fields[0], fields[1]
+	return nil
+}
+
+// Prompts returns the prompts of the UAA login server, keyed by the name of
+// the requested input, e.g. username, password or passcode.
+func (u *UAA) Prompts(ctx context.Context) (map[string]Prompt, error) {
+	if u.AuthorizationEndpoint == "" {
+		return nil, errors.New("authorization endpoint is not known")
+	}
+	loginURL := strings.TrimSuffix(u.AuthorizationEndpoint, "/") + "/login"
+	req, err := http.NewRequest(http.MethodGet, loginURL, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "http.NewRequest failed")
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := u.httpClient().Do(req.WithContext(ctx))
+	if err != nil {
+		return nil, errors.Wrap(err, "doing login request failed")
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("login request failed with status %d", resp.StatusCode)
+	}
+
+	var login struct {
+		Prompts map[string]Prompt `json:"prompts"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&login); err != nil {
+		return nil, errors.Wrap(err, "decoding login response failed")
+	}
+	return login.Prompts, nil
+}
+
+var urlPattern = regexp.MustCompile(`https?://[^\s)]+`)
+
+// PasscodeURL returns the URL at which users can obtain a one-time passcode,
+// as advertised by the passcode prompt of the UAA login server.
+func (u *UAA) PasscodeURL(ctx context.Context) (string, error) {
+	prompts, err := u.Prompts(ctx)
+	if err != nil {
+		return "", err
+	}
+	prompt, ok := prompts["passcode"]
+	if !ok {
+		return "", errors.New("login server does not support passcodes")
+	}
+	if passcodeURL := urlPattern.FindString(prompt.Text); passcodeURL != "" {
+		return passcodeURL, nil
+	}
+	return strings.TrimSuffix(u.AuthorizationEndpoint, "/") + "/passcode", nil
+}
+
+// Passcode obtains a token in exchange for a one-time passcode and returns a
+// Doer that authenticates as the user the passcode was issued to. This is
+// the way to log in users that authenticate with an external identity
+// provider, e.g. via SAML.
+func (u *UAA) Passcode(ctx context.Context, passcode string) (*Doer, error) {
+	form := url.Values{
+		"grant_type": {"password"},
+		"passcode":   {passcode},
+	}
+	req, err := http.NewRequest(http.MethodPost, u.tokenURL(), strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, errors.Wrap(err, "http.NewRequest failed")
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	req.SetBasicAuth(url.QueryEscape(u.ClientID), url.QueryEscape(u.ClientSecret))
+
+	resp, err := u.httpClient().Do(req.WithContext(ctx))
+	if err != nil {
+		return nil, errors.Wrap(err, "doing token request failed")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, grantError("passcode", resp)
+	}
+	var body struct {
+		AccessToken  string `json:"access_token"`
+		TokenType    string `json:"token_type"`
+		RefreshToken string `json:"refresh_token"`
+		ExpiresIn    int64  `json:"expires_in"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return nil, errors.Wrap(err, "decoding token response failed")
+	}
+	if body.AccessToken == "" {
+		return nil, errors.New("passcode grant returned no access token")
+	}
+
+	token := &oauth2.Token{
+		AccessToken:  body.AccessToken,
+		TokenType:    body.TokenType,
+		RefreshToken: body.RefreshToken,
+	}
+	if body.ExpiresIn > 0 {
+		token.Expiry = time.Now().Add(time.Duration(body.ExpiresIn) * time.Second)
+	}
+	return u.newDoer(token, u.refreshTokenGrant), nil
+}
+
+// grantError returns an error describing the failed token response resp. It
+// includes the OAuth error reported by the UAA, or the beginning of the body
+// if the response did not originate from the UAA, e.g. an HTML error page of
+// a proxy.
+func grantError(grant string, resp *http.Response) error {
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 512))
+	if err != nil {
+		return errors.Wrapf(err, "%s grant failed with status %d", grant, resp.StatusCode)
+	}
+	var payload struct {
+		Error            string `json:"error"`
+		ErrorDescription string `json:"error_description"`
+	}
+	if json.Unmarshal(body, &payload) == nil && payload.Error != "" {
+		return errors.Errorf("%s grant failed with status %d: %s %s",
+			grant, resp.StatusCode, payload.Error, payload.ErrorDescription)
+	}
+	return errors.Errorf("%s grant failed with status %d: %q",
+		grant, resp.StatusCode, bytes.TrimSpace(body))
+}
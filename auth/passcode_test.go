@@ -0,0 +1,132 @@
+package auth_test
+
+import (
+	"context"
+	"net/http"
+
+	. "github.com/Bo0mer/ccv2/auth"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("Passcode", func() {
+	var server *ghttp.Server
+	var uaa *UAA
+
+	BeforeEach(func() {
+		server = ghttp.NewServer()
+		uaa = &UAA{
+			AuthorizationEndpoint: server.URL(),
+			TokenEndpoint:         server.URL(),
+			ClientID:              DefaultClientID,
+		}
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	Describe("PasscodeURL", func() {
+		Context("when the login server supports passcodes", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/login"),
+						ghttp.VerifyHeaderKV("Accept", "application/json"),
+						ghttp.RespondWith(http.StatusOK, `
+{
+    "app": {"version": "4.30.0"},
+    "prompts": {
+        "username": ["text", "Email"],
+        "password": ["password", "Password"],
+        "passcode": ["password", "Temporary Authentication Code ( Get one at https://login.example.com/passcode )"]
+    }
+}`),
+					),
+				)
+			})
+
+			It("should have returned the URL from the prompt", func() {
+				passcodeURL, err := uaa.PasscodeURL(context.Background())
+				Ω(err).ShouldNot(HaveOccurred())
+				Ω(passcodeURL).Should(Equal("https://login.example.com/passcode"))
+			})
+		})
+
+		Context("when the login server does not support passcodes", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.RespondWith(http.StatusOK, `{"prompts": {"username": ["text", "Email"]}}`),
+				)
+			})
+
+			It("should have returned an error", func() {
+				_, err := uaa.PasscodeURL(context.Background())
+				Ω(err).Should(MatchError("login server does not support passcodes"))
+			})
+		})
+	})
+
+	Describe("Passcode", func() {
+		Context("when the passcode is valid", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("POST", "/oauth/token"),
+						ghttp.VerifyBasicAuth("cf", ""),
+						ghttp.VerifyForm(map[string][]string{
+							"grant_type": {"password"},
+							"passcode":   {"s3cr3t"},
+						}),
+						ghttp.RespondWith(http.StatusOK, `
+{
+    "access_token": "sso-token",
+    "token_type": "bearer",
+    "refresh_token": "sso-refresh-token",
+    "expires_in": 3600
+}`),
+					),
+				)
+			})
+
+			It("should have obtained a token", func() {
+				doer, err := uaa.Passcode(context.Background(), "s3cr3t")
+				Ω(err).ShouldNot(HaveOccurred())
+				token, err := doer.Token()
+				Ω(err).ShouldNot(HaveOccurred())
+				Ω(token.AccessToken).Should(Equal("sso-token"))
+				Ω(token.RefreshToken).Should(Equal("sso-refresh-token"))
+			})
+		})
+
+		Context("when the passcode is invalid", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.RespondWith(http.StatusUnauthorized, `{"error": "unauthorized", "error_description": "Bad credentials"}`),
+				)
+			})
+
+			It("should have returned an error", func() {
+				_, err := uaa.Passcode(context.Background(), "wrong")
+				Ω(err).Should(MatchError(ContainSubstring("Bad credentials")))
+			})
+		})
+
+		Context("when a proxy fails the request", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.RespondWith(http.StatusBadGateway, "<html><body>502 Bad Gateway</body></html>"),
+				)
+			})
+
+			It("should have returned the status and the beginning of the body", func() {
+				_, err := uaa.Passcode(context.Background(), "passcode")
+				Ω(err).Should(MatchError(ContainSubstring("status 502")))
+				Ω(err).Should(MatchError(ContainSubstring("502 Bad Gateway</body>")))
+				Ω(err).ShouldNot(MatchError(ContainSubstring("decoding")))
+			})
+		})
+	})
+})
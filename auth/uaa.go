@@ -0,0 +1,150 @@
+package auth
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/Bo0mer/ccv2"
+	"github.com/pkg/errors"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/clientcredentials"
+)
+
+// DefaultClientID is the ID of the UAA client used by the cf CLI.
+const DefaultClientID = "cf"
+
+// UAA obtains tokens from a UAA server.
+type UAA struct {
+	// AuthorizationEndpoint is the URL of the UAA login server.
+	AuthorizationEndpoint string
+	// TokenEndpoint is the URL of the UAA server.
+	TokenEndpoint string
+
+	// ClientID and ClientSecret are the credentials of the UAA client on
+	// whose behalf tokens are requested.
+	ClientID     string
+	ClientSecret string
+
+	// HTTPClient is used for requests to the UAA and for the requests sent
+	// by the Doers created by the UAA. If nil, http.DefaultClient is used.
+	HTTPClient *http.Client
+}
+
+// Discover returns the UAA used by the Cloud Controller targeted by cc. The
+// returned UAA uses the cf CLI client. The Cloud Controller info endpoint
+// does not require authentication, so cc may use an unauthenticated HTTP
+// client.
+func Discover(ctx context.Context, cc *ccv2.Client) (*UAA, error) {
+	info, err := cc.Info(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "fetching info failed")
+	}
+	if info.TokenEndpoint == "" {
+		return nil, errors.New("info does not specify token endpoint")
+	}
+	return &UAA{
+		AuthorizationEndpoint: info.AuthorizationEndpoint,
+		TokenEndpoint:         info.TokenEndpoint,
+		ClientID:              DefaultClientID,
+	}, nil
+}
+
+// Password obtains a token using the password grant and returns a Doer that
+// authenticates as the specified user.
+func (u *UAA) Password(ctx context.Context, username, password string) (*Doer, error) {
+	token, err := u.config().PasswordCredentialsToken(u.context(ctx), username, password)
+	if err != nil {
+		return nil, errors.Wrap(err, "password grant failed")
+	}
+	return u.newDoer(token, u.refreshTokenGrant), nil
+}
+
+// ClientCredentials obtains a token using the client credentials grant and
+// returns a Doer that authenticates as the client of u.
+func (u *UAA) ClientCredentials(ctx context.Context) (*Doer, error) {
+	token, err := u.clientCredentialsGrant(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	return u.newDoer(token, u.clientCredentialsGrant), nil
+}
+
+// RefreshToken obtains a token using the refresh token grant and returns a
+// Doer that authenticates as the owner of refreshToken.
+func (u *UAA) RefreshToken(ctx context.Context, refreshToken string) (*Doer, error) {
+	token, err := u.refreshTokenGrant(ctx, &oauth2.Token{RefreshToken: refreshToken})
+	if err != nil {
+		return nil, err
+	}
+	return u.newDoer(token, u.refreshTokenGrant), nil
+}
+
+// Doer returns a Doer that authenticates using an already obtained token,
+// refreshing it using its refresh token when needed. If token is nil, the
+// requests of the Doer fail.
+func (u *UAA) Doer(token *oauth2.Token) *Doer {
+	return u.newDoer(token, u.refreshTokenGrant)
+}
+
+func (u *UAA) newDoer(token *oauth2.Token, refresh refreshFunc) *Doer {
+	return &Doer{
+		client:  u.httpClient(),
+		token:   token,
+		refresh: refresh,
+	}
+}
+
+func (u *UAA) refreshTokenGrant(ctx context.Context, token *oauth2.Token) (*oauth2.Token, error) {
+	if token == nil || token.RefreshToken == "" {
+		return nil, errors.New("token cannot be refreshed")
+	}
+	expired := &oauth2.Token{RefreshToken: token.RefreshToken}
+	newToken, err := u.config().TokenSource(u.context(ctx), expired).Token()
+	if err != nil {
+		return nil, errors.Wrap(err, "refresh token grant failed")
+	}
+	return newToken, nil
+}
+
+func (u *UAA) clientCredentialsGrant(ctx context.Context, _ *oauth2.Token) (*oauth2.Token, error) {
+	config := &clientcredentials.Config{
+		ClientID:     u.ClientID,
+		ClientSecret: u.ClientSecret,
+		TokenURL:     u.tokenURL(),
+		AuthStyle:    oauth2.AuthStyleInHeader,
+	}
+	token, err := config.Token(u.context(ctx))
+	if err != nil {
+		return nil, errors.Wrap(err, "client credentials grant failed")
+	}
+	return token, nil
+}
+
+func (u *UAA) config() *oauth2.Config {
+	return &oauth2.Config{
+		ClientID:     u.ClientID,
+		ClientSecret: u.ClientSecret,
+		Endpoint: oauth2.Endpoint{
+			AuthURL:   strings.TrimSuffix(u.TokenEndpoint, "/") + "/oauth/authorize",
+			TokenURL:  u.tokenURL(),
+			AuthStyle: oauth2.AuthStyleInHeader,
+		},
+	}
+}
+
+func (u *UAA) tokenURL() string {
+	return strings.TrimSuffix(u.TokenEndpoint, "/") + "/oauth/token"
+}
+
+// context returns a context that makes the oauth2 package use u.HTTPClient.
+func (u *UAA) context(ctx context.Context) context.Context {
+	return context.WithValue(ctx, oauth2.HTTPClient, u.httpClient())
+}
+
+func (u *UAA) httpClient() *http.Client {
+	if u.HTTPClient != nil {
+		return u.HTTPClient
+	}
+	return http.DefaultClient
+}
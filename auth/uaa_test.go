@@ -0,0 +1,183 @@
+package auth_test
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	"github.com/Bo0mer/ccv2"
+	. "github.com/Bo0mer/ccv2/auth"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("UAA", func() {
+	var server *ghttp.Server
+	var cc *ccv2.Client
+
+	var uaa *UAA
+	var err error
+
+	tokenHandler := func(grantType, accessToken string) http.HandlerFunc {
+		return ghttp.CombineHandlers(
+			ghttp.VerifyRequest("POST", "/oauth/token"),
+			ghttp.VerifyBasicAuth("cf", ""),
+			func(w http.ResponseWriter, r *http.Request) {
+				Ω(r.ParseForm()).Should(Succeed())
+				Ω(r.PostForm.Get("grant_type")).Should(Equal(grantType))
+			},
+			ghttp.RespondWithJSONEncoded(http.StatusOK, map[string]interface{}{
+				"access_token":  accessToken,
+				"refresh_token": "refresh-token",
+				"token_type":    "bearer",
+				"expires_in":    3600,
+			}),
+		)
+	}
+
+	BeforeEach(func() {
+		server = ghttp.NewServer()
+		u, perr := url.Parse(server.URL())
+		Ω(perr).ShouldNot(HaveOccurred())
+		cc = &ccv2.Client{
+			API:        u,
+			HTTPClient: http.DefaultClient,
+		}
+
+		server.AppendHandlers(
+			ghttp.CombineHandlers(
+				ghttp.VerifyRequest("GET", "/v2/info"),
+				ghttp.RespondWithJSONEncoded(http.StatusOK, ccv2.Info{
+					AuthorizationEndpoint: server.URL(),
+					TokenEndpoint:         server.URL(),
+				}),
+			),
+		)
+		uaa, err = Discover(context.Background(), cc)
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("should have discovered the UAA", func() {
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(uaa.TokenEndpoint).Should(Equal(server.URL()))
+		Ω(uaa.AuthorizationEndpoint).Should(Equal(server.URL()))
+		Ω(uaa.ClientID).Should(Equal(DefaultClientID))
+	})
+
+	Describe("Password", func() {
+		var doer *Doer
+
+		BeforeEach(func() {
+			server.AppendHandlers(tokenHandler("password", "access-token"))
+			doer, err = uaa.Password(context.Background(), "admin", "secret")
+			Ω(err).ShouldNot(HaveOccurred())
+			cc.HTTPClient = doer
+		})
+
+		Context("when the Cloud Controller accepts the token", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/v2/organizations"),
+						ghttp.VerifyHeaderKV("Authorization", "Bearer access-token"),
+						ghttp.RespondWith(http.StatusOK, `{"resources": []}`),
+					),
+				)
+			})
+
+			It("should have authenticated the request", func() {
+				_, err := cc.Organizations(context.Background())
+				Ω(err).ShouldNot(HaveOccurred())
+			})
+		})
+
+		Context("when the Cloud Controller rejects the token as invalid", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.RespondWith(http.StatusUnauthorized, `{"error_code": "CF-InvalidAuthToken", "code": 1000}`),
+					tokenHandler("refresh_token", "new-access-token"),
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/v2/organizations"),
+						ghttp.VerifyHeaderKV("Authorization", "Bearer new-access-token"),
+						ghttp.RespondWith(http.StatusOK, `{"resources": []}`),
+					),
+				)
+			})
+
+			It("should have refreshed the token and retried the request", func() {
+				_, err := cc.Organizations(context.Background())
+				Ω(err).ShouldNot(HaveOccurred())
+
+				token, err := doer.Token()
+				Ω(err).ShouldNot(HaveOccurred())
+				Ω(token.AccessToken).Should(Equal("new-access-token"))
+			})
+		})
+
+		Context("when the Cloud Controller rejects the request for another reason", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.RespondWith(http.StatusUnauthorized, `{"error_code": "CF-NotAuthenticated", "code": 10002}`),
+				)
+			})
+
+			It("should have returned the response", func() {
+				_, err := cc.Organizations(context.Background())
+				var e *ccv2.UnexpectedResponseError
+				Ω(err).Should(BeAssignableToTypeOf(e))
+				e = err.(*ccv2.UnexpectedResponseError)
+				Ω(e.ErrorCode).Should(Equal(ccv2.ErrorCodeNotAuthenticated))
+			})
+		})
+	})
+
+	Describe("ClientCredentials", func() {
+		BeforeEach(func() {
+			server.AppendHandlers(tokenHandler("client_credentials", "client-token"))
+		})
+
+		It("should have obtained a token", func() {
+			doer, err := uaa.ClientCredentials(context.Background())
+			Ω(err).ShouldNot(HaveOccurred())
+			token, err := doer.Token()
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(token.AccessToken).Should(Equal("client-token"))
+
+			var ts ccv2.AccessTokenSource = doer
+			accessToken, err := ts.AccessToken()
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(accessToken).Should(Equal("client-token"))
+		})
+	})
+
+	Describe("RefreshToken", func() {
+		BeforeEach(func() {
+			server.AppendHandlers(tokenHandler("refresh_token", "refreshed-token"))
+		})
+
+		It("should have obtained a token", func() {
+			doer, err := uaa.RefreshToken(context.Background(), "refresh-token")
+			Ω(err).ShouldNot(HaveOccurred())
+			token, err := doer.Token()
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(token.AccessToken).Should(Equal("refreshed-token"))
+		})
+	})
+
+	Describe("Doer", func() {
+		Context("when no token is provided", func() {
+			It("should have returned an error instead of panicking", func() {
+				sent := len(server.ReceivedRequests())
+				doer := uaa.Doer(nil)
+				_, err := doer.Token()
+				Ω(err).Should(MatchError(ContainSubstring("token cannot be refreshed")))
+				Ω(server.ReceivedRequests()).Should(HaveLen(sent))
+			})
+		})
+	})
+})
@@ -3,94 +3,52 @@ package ccv2
 import (
 	"context"
 	"encoding/json"
-	"fmt"
 	"io"
+	"iter"
 	"net/http"
 	"net/url"
+	"strconv"
+	"sync"
 
 	"github.com/pkg/errors"
 )
 
 //go:generate counterfeiter . Doer
 
-// Filter specifies the target of a query.
-type Filter string
-
-const (
-	// FilterName specifies that the query should filter on the name field.
-	FilterName Filter = "name"
-	// FilterOrganizationGUID specifies that the query should filter on the
-	// organization_guid field.
-	FilterOrganizationGUID = "organization_guid"
-	// FilterSpaceGUID specifies that the query should filter on the space_guid
-	// field.
-	FilterSpaceGUID = "space_guid"
-	// FilterTimestamp specifies that the query should filter on the timestamp
-	// field.
-	FilterTimestamp = "timestamp"
-	// FilterActee specifies that the query should filter on the actee field.
-	FilterActee = "actee"
-	// FilterActor specifies that the query should filter on the actor field.
-	FilterActor = "actor"
-	// FilterType specifies that the query should filter on the type field.
-	FilterType = "type"
-)
-
-// Operator specifies an operator for a query.
-type Operator string
-
-const (
-	// OperatorEqual specifies that the result should match the value.
-	OperatorEqual Operator = ":"
-	// OperatorGreater specifies that the result should be greater than the value.
-	OperatorGreater = ">"
-	// OperatorLess specifies that the result should be less than the value.
-	OperatorLess = "<"
-)
-
-// Query gives means to filter list of resources.
-type Query struct {
-	// Filter is the field on which the query will act.
-	Filter Filter
-	// Op is the operator that will be applied during filtering.
-	Op Operator
-	// Value is the value that will be used when applying the Op.
-	Value string
-}
-
-// String returns the string representation of the query.
-// It is of the form <Filter><Op><Value>.
-// It must be query encoded if to be used as a query param.
-func (q Query) String() string {
-	return string(q.Filter) + string(q.Op) + q.Value
-}
-
 // Doer does HTTP requests and returns the corresponding responses.
 type Doer interface {
 	Do(*http.Request) (*http.Response, error)
 }
 
-// UnexpectedResponseError wraps response that indicates error.
-type UnexpectedResponseError struct {
-	StatusCode  int    `json:"status_code"`
-	Description string `json:"description"`
-	ErrorCode   string `json:"error_code"`
-}
-
-// Error returns a description of the error.
-func (e *UnexpectedResponseError) Error() string {
-	return e.Description
-}
-
 type paginatedResource struct {
-	NextURL   string          `json:"next_url"`
-	Resources json.RawMessage `json:"resources"`
+	TotalResults int             `json:"total_results"`
+	TotalPages   int             `json:"total_pages"`
+	NextURL      string          `json:"next_url"`
+	Resources    json.RawMessage `json:"resources"`
 }
 
 // Client implements a read-only Cloud Controller client.
 type Client struct {
 	API        *url.URL
 	HTTPClient Doer
+
+	// PageConcurrency is the maximum number of pages that are fetched
+	// concurrently when listing resources. When it is greater than one, the
+	// first page is fetched and the rest are requested by page number, using
+	// the total_pages reported by the Cloud Controller. Pages are still
+	// processed in order. Zero or one means that pages are fetched
+	// sequentially, by following next_url. Iterators, such as EachEvent,
+	// always fetch pages sequentially, so that they hold at most one page in
+	// memory.
+	PageConcurrency int
+
+	// Retry specifies how requests failing due to transient errors are
+	// retried. If nil, requests are not retried.
+	Retry *RetryPolicy
+
+	// Limiter limits the rate and concurrency of the requests sent by all
+	// methods of the client. If nil, requests are not limited.
+	Limiter *RateLimiter
 }
 
 type requestOpts struct {
@@ -98,114 +56,74 @@ type requestOpts struct {
 	Method  string
 	Path    string
 	Queries []Query
+	Params  url.Values
 	Body    io.Reader
+	// Sequential disables concurrent pagination for the request.
+	Sequential bool
 }
 
 // Info returns the info returned by the Cloud Controller info endpoint.
-func (c *Client) Info(ctx context.Context) (i Info, err error) {
-	req, err := c.newRequest(requestOpts{
+func (c *Client) Info(ctx context.Context) (Info, error) {
+	var info Info
+	err := c.get(requestOpts{
 		Context: ctx,
 		Method:  http.MethodGet,
 		Path:    "/v2/info",
-	})
+	}, &info)
 	if err != nil {
 		return Info{}, err
 	}
-
-	resp, err := c.HTTPClient.Do(req)
-	if err != nil {
-		return Info{}, errors.Wrap(err, "doing info request failed")
-	}
-	defer func() {
-		if cerr := resp.Body.Close(); cerr != nil && err == nil {
-			err = cerr
-		}
-	}()
-
-	if resp.StatusCode != 200 {
-		return Info{}, errFromResponse(resp)
-	}
-
-	var info Info
-	err = json.NewDecoder(resp.Body).Decode(&info)
-	return info, err
+	return info, nil
 }
 
-// Organizations list all organizations that conform to the provided queries.
-func (c *Client) Organizations(ctx context.Context, queries ...Query) ([]Organization, error) {
-	var orgs []Organization
-	orgCb := func(resources json.RawMessage) error {
-		var res []Organization
-		err := json.Unmarshal(resources, &res)
-		orgs = append(orgs, res...)
-		return err
-	}
-
-	opts := requestOpts{
+// Get fetches the resource at path, which is resolved against the API URL,
+// and decodes it into out. It is meant for endpoints that are not modelled
+// by this package, e.g. /v2/stacks/:guid.
+func (c *Client) Get(ctx context.Context, path string, queries []Query, out interface{}) error {
+	return c.get(requestOpts{
 		Context: ctx,
 		Method:  http.MethodGet,
-		Path:    "/v2/organizations",
+		Path:    path,
 		Queries: queries,
-	}
-	err := c.paginate(opts, orgCb)
-	return orgs, err
+	}, out)
 }
 
-// Spaces list all spaces that conform to the provided queries.
-func (c *Client) Spaces(ctx context.Context, queries ...Query) ([]Space, error) {
-	var spaces []Space
-	spaceCb := func(resources json.RawMessage) error {
-		var res []Space
-		err := json.Unmarshal(resources, &res)
-		spaces = append(spaces, res...)
-		return err
-	}
-
-	opts := requestOpts{
+// List lists the collection at path, which is resolved against the API URL,
+// following next_url until all pages are fetched. For each page, pageCb is
+// invoked with the raw JSON array of its resources. It is meant for
+// endpoints that are not modelled by this package, e.g. /v2/stacks.
+func (c *Client) List(ctx context.Context, path string, queries []Query, pageCb func(json.RawMessage) error) error {
+	return c.paginate(requestOpts{
 		Context: ctx,
 		Method:  http.MethodGet,
-		Path:    "/v2/spaces",
+		Path:    path,
 		Queries: queries,
-	}
-	err := c.paginate(opts, spaceCb)
-	return spaces, err
+	}, pageCb)
 }
 
-// Applications list all applications that conform to the provided queries.
-func (c *Client) Applications(ctx context.Context, queries ...Query) ([]Application, error) {
-	var apps []Application
-	appsCb := func(resources json.RawMessage) error {
-		var res []Application
-		err := json.Unmarshal(resources, &res)
-		apps = append(apps, res...)
-		return err
+// getResource fetches the resource with the given GUID from the collection
+// at path and decodes it into out.
+func (c *Client) getResource(ctx context.Context, path, guid string, out interface{}) error {
+	if guid == "" {
+		return errors.New("guid is empty")
 	}
-
-	opts := requestOpts{
+	return c.get(requestOpts{
 		Context: ctx,
 		Method:  http.MethodGet,
-		Path:    "/v2/apps",
-		Queries: queries,
-	}
-	err := c.paginate(opts, appsCb)
-	return apps, err
+		Path:    path + "/" + url.PathEscape(guid),
+	}, out)
 }
 
-// ApplicationSummary returns summary for a given application.
-func (c *Client) ApplicationSummary(ctx context.Context, app Application) (a ApplicationSummary, err error) {
-	opts := requestOpts{
-		Context: ctx,
-		Method:  http.MethodGet,
-		Path:    fmt.Sprintf("/v2/apps/%s/summary", app.GUID),
-	}
+// get fetches a single resource and decodes it into out.
+func (c *Client) get(opts requestOpts, out interface{}) (err error) {
 	req, err := c.newRequest(opts)
 	if err != nil {
-		return ApplicationSummary{}, err
+		return err
 	}
 
-	resp, err := c.HTTPClient.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
-		return ApplicationSummary{}, errors.Wrap(err, "doing summary request failed")
+		return errors.Wrap(err, "doing request failed")
 	}
 	defer func() {
 		if cerr := resp.Body.Close(); cerr != nil && err == nil {
@@ -213,32 +131,10 @@ func (c *Client) ApplicationSummary(ctx context.Context, app Application) (a App
 		}
 	}()
 	if resp.StatusCode != http.StatusOK {
-		return ApplicationSummary{}, errFromResponse(resp)
-	}
-
-	var summary ApplicationSummary
-	err = json.NewDecoder(resp.Body).Decode(&summary)
-	return summary, errors.Wrap(err, "decoding response failed")
-}
-
-// Events list all events that conform to the provided queries.
-func (c *Client) Events(ctx context.Context, queries ...Query) ([]Event, error) {
-	var events []Event
-	eventCb := func(resources json.RawMessage) error {
-		var res []Event
-		err := json.Unmarshal(resources, &res)
-		events = append(events, res...)
-		return err
+		return errFromResponse(resp)
 	}
 
-	opts := requestOpts{
-		Context: ctx,
-		Method:  http.MethodGet,
-		Path:    "/v2/events",
-		Queries: queries,
-	}
-	err := c.paginate(opts, eventCb)
-	return events, err
+	return errors.Wrap(json.NewDecoder(resp.Body).Decode(out), "decoding response failed")
 }
 
 func (c *Client) newRequest(opts requestOpts) (*http.Request, error) {
@@ -246,10 +142,23 @@ func (c *Client) newRequest(opts requestOpts) (*http.Request, error) {
 	if err != nil {
 		return nil, errors.Wrapf(err, "parsing url for path %q failed", opts.Path)
 	}
+	queries, params, err := splitQueries(opts.Queries)
+	if err != nil {
+		return nil, err
+	}
 	q := url.Query()
-	for _, query := range opts.Queries {
+	for _, query := range queries {
+		if err := query.validate(); err != nil {
+			return nil, errors.Wrap(err, "invalid query")
+		}
 		q.Add("q", query.String())
 	}
+	for key, values := range params {
+		q[key] = values
+	}
+	for key, values := range opts.Params {
+		q[key] = values
+	}
 	url.RawQuery = q.Encode()
 	req, err := http.NewRequest(opts.Method, url.String(), opts.Body)
 	if err != nil {
@@ -258,46 +167,174 @@ func (c *Client) newRequest(opts requestOpts) (*http.Request, error) {
 	return req.WithContext(opts.Context), nil
 }
 
-func (c *Client) paginate(opts requestOpts, pageCb func(json.RawMessage) error) (err error) {
-	for {
-		req, err := c.newRequest(opts)
+func (c *Client) paginate(opts requestOpts, pageCb func(json.RawMessage) error) error {
+	queries, params, err := splitQueries(opts.Queries)
+	if err != nil {
+		return err
+	}
+	if err := validateQueries(opts.Path, queries); err != nil {
+		return errors.Wrap(err, "invalid query")
+	}
+	// The list options are kept as parameters, so that they apply to every
+	// page even if the next_url does not carry them.
+	for key, values := range opts.Params {
+		params[key] = values
+	}
+	opts.Queries, opts.Params = queries, params
+	first := opts
+	for n := 1; ; n++ {
+		page, err := c.page(opts)
 		if err != nil {
-			return errors.Wrap(err, "creating page request failed")
+			return err
 		}
-		resp, err := c.HTTPClient.Do(req)
-		if err != nil {
-			return errors.Wrap(err, "doing page request failed")
+		if err := pageCb(page.Resources); err != nil {
+			return errors.Wrap(err, "page content processor failed")
+		}
+		if page.NextURL == "" {
+			return nil
 		}
-		defer func(resp *http.Response) {
-			if cerr := resp.Body.Close(); cerr != nil && err == nil {
-				err = cerr
+		if n == 1 && !opts.Sequential && c.PageConcurrency > 1 && page.TotalPages > 1 {
+			return c.paginateConcurrently(first, page.TotalPages, pageCb)
+		}
+		opts.Path = page.NextURL
+		// The next_url already carries the queries of the original request.
+		opts.Queries = nil
+	}
+}
+
+// paginateConcurrently fetches pages 2 to totalPages of the listing described
+// by opts. At most c.PageConcurrency pages are being fetched or waiting to be
+// processed at any time, and pageCb is invoked for them in order.
+func (c *Client) paginateConcurrently(opts requestOpts, totalPages int, pageCb func(json.RawMessage) error) error {
+	ctx, cancel := context.WithCancel(opts.Context)
+	opts.Context = ctx
+
+	type result struct {
+		page paginatedResource
+		err  error
+	}
+	results := make([]chan result, totalPages-1)
+	for i := range results {
+		results[i] = make(chan result, 1)
+	}
+	window := make(chan struct{}, c.PageConcurrency)
+
+	var wg sync.WaitGroup
+	defer func() {
+		cancel()
+		wg.Wait()
+	}()
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := range results {
+			select {
+			case window <- struct{}{}:
+			case <-ctx.Done():
+				return
+			}
+			pageOpts := opts
+			pageOpts.Params = url.Values{}
+			for key, values := range opts.Params {
+				pageOpts.Params[key] = values
 			}
-		}(resp)
+			pageOpts.Params.Set("page", strconv.Itoa(i+2))
+
+			wg.Add(1)
+			go func(res chan<- result) {
+				defer wg.Done()
+				page, err := c.page(pageOpts)
+				res <- result{page: page, err: err}
+			}(results[i])
+		}
+	}()
 
-		if resp.StatusCode != http.StatusOK {
-			return errFromResponse(resp)
+	for _, res := range results {
+		var r result
+		select {
+		case r = <-res:
+		case <-ctx.Done():
+			return errors.Wrap(ctx.Err(), "waiting for page failed")
 		}
-		var page paginatedResource
-		if err := json.NewDecoder(resp.Body).Decode(&page); err != nil {
-			return errors.Wrap(err, "page response decoding failed")
+		if r.err != nil {
+			return r.err
 		}
-		if err := pageCb(page.Resources); err != nil {
+		if err := pageCb(r.page.Resources); err != nil {
 			return errors.Wrap(err, "page content processor failed")
 		}
-		if page.NextURL == "" {
-			break
-		}
-		opts.Path = page.NextURL
+		<-window
 	}
 	return nil
 }
 
-func errFromResponse(resp *http.Response) error {
-	e := &UnexpectedResponseError{
-		StatusCode: resp.StatusCode,
+// page fetches a single page. The response body is closed before it returns,
+// so that no more than one page is held open at a time.
+func (c *Client) page(opts requestOpts) (p paginatedResource, err error) {
+	req, err := c.newRequest(opts)
+	if err != nil {
+		return paginatedResource{}, errors.Wrap(err, "creating page request failed")
+	}
+	resp, err := c.do(req)
+	if err != nil {
+		return paginatedResource{}, errors.Wrap(err, "doing page request failed")
+	}
+	defer func() {
+		if cerr := resp.Body.Close(); cerr != nil && err == nil {
+			err = cerr
+		}
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		return paginatedResource{}, errFromResponse(resp)
+	}
+	var page paginatedResource
+	if err := json.NewDecoder(resp.Body).Decode(&page); err != nil {
+		return paginatedResource{}, errors.Wrap(err, "page response decoding failed")
 	}
-	if err := json.NewDecoder(resp.Body).Decode(e); err != nil {
-		e.Description = err.Error()
+	return page, nil
+}
+
+// list returns all resources listed at opts.Path.
+func list[T any](c *Client, opts requestOpts) ([]T, error) {
+	var all []T
+	err := c.paginate(opts, func(resources json.RawMessage) error {
+		var res []T
+		err := json.Unmarshal(resources, &res)
+		all = append(all, res...)
+		return err
+	})
+	return all, err
+}
+
+// errStopIteration is returned by page callbacks of iterators when the
+// consumer is no longer interested in further resources.
+var errStopIteration = errors.New("iteration stopped")
+
+// each returns an iterator over the resources listed at opts.Path. Pages are
+// requested lazily, only after the consumer has processed all resources of
+// the previous page, regardless of c.PageConcurrency. If listing fails, the
+// error is yielded as the last element of the sequence.
+func each[T any](c *Client, opts requestOpts) iter.Seq2[T, error] {
+	opts.Sequential = true
+	return func(yield func(T, error) bool) {
+		stopped := false
+		err := c.paginate(opts, func(resources json.RawMessage) error {
+			var res []T
+			if err := json.Unmarshal(resources, &res); err != nil {
+				return err
+			}
+			for _, r := range res {
+				if !yield(r, nil) {
+					stopped = true
+					return errStopIteration
+				}
+			}
+			return nil
+		})
+		if err != nil && !stopped {
+			var zero T
+			yield(zero, err)
+		}
 	}
-	return e
 }
@@ -1,11 +1,18 @@
 package ccv2_test
 
 import (
+	"context"
+	"encoding/json"
 	"net/http"
 	"net/url"
+	"time"
 
 	"github.com/Bo0mer/ccv2"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
 	"github.com/onsi/gomega/ghttp"
+	. "github.com/onsi/gomega/gstruct"
+	"github.com/onsi/gomega/types"
 )
 
 func setupTestClientAndServer() (*ccv2.Client, *ghttp.Server) {
@@ -26,8 +33,106 @@ func notFoundHandler() http.HandlerFunc {
 	return ghttp.RespondWith(http.StatusNotFound, `{"error_code":"10001","description":"Entity is missing."}`)
 }
 
-var notFoundErr = &ccv2.UnexpectedResponseError{
-	StatusCode:  http.StatusNotFound,
-	ErrorCode:   "10001",
-	Description: "Entity is missing.",
+// beNotFoundErr matches the error produced by notFoundHandler, regardless of
+// the request that has failed.
+func beNotFoundErr() types.GomegaMatcher {
+	return PointTo(MatchFields(IgnoreExtras, Fields{
+		"StatusCode":  Equal(http.StatusNotFound),
+		"ErrorCode":   Equal("10001"),
+		"Description": Equal("Entity is missing."),
+	}))
+}
+
+var _ = Describe("Client", func() {
+	var client *ccv2.Client
+	var server *ghttp.Server
+
+	BeforeEach(func() {
+		client, server = setupTestClientAndServer()
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	Describe("Get", func() {
+		var stack struct {
+			Metadata ccv2.Metadata `json:"metadata"`
+			Entity   struct {
+				Name string `json:"name"`
+			} `json:"entity"`
+		}
+		var err error
+
+		JustBeforeEach(func() {
+			err = client.Get(context.Background(), "/v2/stacks/stack-guid", nil, &stack)
+		})
+
+		Context("when the server returns a valid response", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/v2/stacks/stack-guid"),
+						ghttp.RespondWith(http.StatusOK, `{"metadata": {"guid": "stack-guid"}, "entity": {"name": "cflinuxfs2"}}`),
+					),
+				)
+			})
+
+			It("should have decoded the resource", func() {
+				Ω(err).ShouldNot(HaveOccurred())
+				Ω(stack.Metadata.GUID).Should(Equal("stack-guid"))
+				Ω(stack.Entity.Name).Should(Equal("cflinuxfs2"))
+			})
+		})
+
+		Context("when the server returns a non-2XX response", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(notFoundHandler())
+			})
+
+			It("should have returned a UnexpectedResponseError", func() {
+				Ω(err).Should(beNotFoundErr())
+			})
+		})
+	})
+
+	Describe("List", func() {
+		var pages []string
+		var err error
+
+		BeforeEach(func() {
+			pages = nil
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/v2/stacks", "q=name%3Acflinuxfs2"),
+					ghttp.RespondWith(http.StatusOK, `{"next_url": "/v2/stacks?page=2&q=name%3Acflinuxfs2", "resources": [1]}`),
+				),
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/v2/stacks", "page=2&q=name%3Acflinuxfs2"),
+					ghttp.RespondWith(http.StatusOK, `{"next_url": null, "resources": [2]}`),
+				),
+			)
+		})
+
+		JustBeforeEach(func() {
+			queries := []ccv2.Query{ccv2.Eq(ccv2.FilterName, "cflinuxfs2")}
+			err = client.List(context.Background(), "/v2/stacks", queries, func(resources json.RawMessage) error {
+				pages = append(pages, string(resources))
+				return nil
+			})
+		})
+
+		It("should have processed all pages", func() {
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(pages).Should(Equal([]string{"[1]", "[2]"}))
+		})
+	})
+})
+
+func parseTime(s string) time.Time {
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		panic(err)
+	}
+	return t
 }
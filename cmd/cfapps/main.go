@@ -1,70 +1,57 @@
 // cfapps is a simple command line utility that prints one's Cloud Foundry
 // organizations and applications.
 //
+// Invoked as
+//
+//	cfapps [flags] access [-since duration] <username or user GUID>
+//
+// it instead prints the roles of a user, the applications in the spaces in
+// which the user holds a role and the events the user caused recently.
+//
 // It's purpose is just to demonstrate how to use package ccv2.
 package main
 
 import (
+	"bufio"
 	"context"
 	"flag"
 	"fmt"
 	"log"
 	"net/http"
 	"net/url"
+	"os"
+	"strings"
 	"time"
 
 	"github.com/Bo0mer/ccv2"
-	"golang.org/x/oauth2"
+	"github.com/Bo0mer/ccv2/auth"
 )
 
 var api string
 var username string
 var password string
+var sso bool
 
 func init() {
-	flag.StringVar(&api, "api", "https://api.bosh-lite.com", "URL of the CC.")
-	flag.StringVar(&username, "username", "admin", "Username.")
-	flag.StringVar(&password, "password", "admin", "Password.")
+	flag.StringVar(&api, "api", "https://api.bosh-lite.com", "URL of the CC. Used only with -username or -sso.")
+	flag.StringVar(&username, "username", "", "Username. If empty, the credentials of the cf CLI are used.")
+	flag.StringVar(&password, "password", "", "Password.")
+	flag.BoolVar(&sso, "sso", false, "Log in with a one-time passcode obtained via single sign-on.")
 	log.SetFlags(0)
 }
 
 func main() {
 	flag.Parse()
 
-	apiURL, err := url.Parse(api)
-	if err != nil {
-		log.Fatalf("error parsing api url: %v\n", err)
-	}
-
-	cf := &ccv2.Client{
-		API:        apiURL,
-		HTTPClient: http.DefaultClient,
-	}
 	ctx := context.Background()
-	infoCtx, cancel := context.WithTimeout(ctx, time.Second*5)
-	defer cancel()
-	info, err := cf.Info(infoCtx)
+	cf, err := newClient(ctx)
 	if err != nil {
-		log.Fatalf("error fetching info: %v\n", err)
+		log.Fatalf("error creating client: %v\n", err)
 	}
 
-	authConfig := &oauth2.Config{
-		ClientID: "cf",
-		Scopes:   []string{""},
-		Endpoint: oauth2.Endpoint{
-			AuthURL:  info.TokenEndpoint + "/oauth/auth",
-			TokenURL: info.TokenEndpoint + "/oauth/token",
-		},
-	}
-	tokenCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
-	defer cancel()
-	token, err := authConfig.PasswordCredentialsToken(tokenCtx, username, password)
-	if err != nil {
-		log.Fatalf("error fetching oauth2 token: %v\n", err)
-	}
-	cf = &ccv2.Client{
-		API:        apiURL,
-		HTTPClient: authConfig.Client(ctx, token),
+	if flag.Arg(0) == "access" {
+		printAccess(ctx, cf, flag.Args()[1:])
+		return
 	}
 
 	orgsCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
@@ -89,3 +76,110 @@ func main() {
 		fmt.Printf("%s\n", app.Entity.Name)
 	}
 }
+
+// printAccess prints the access report of the user given in args.
+func printAccess(ctx context.Context, cf *ccv2.Client, args []string) {
+	fs := flag.NewFlagSet("access", flag.ExitOnError)
+	since := fs.Duration("since", 30*24*time.Hour, "Print events that occurred within this duration.")
+	fs.Parse(args)
+	if fs.NArg() != 1 {
+		log.Fatalf("usage: cfapps access [-since duration] <username or user GUID>\n")
+	}
+
+	reportCtx, cancel := context.WithTimeout(ctx, time.Minute)
+	defer cancel()
+	now := time.Now()
+	report, err := cf.UserAccess(reportCtx, fs.Arg(0), now.Add(-*since), now)
+	if err != nil {
+		log.Fatalf("error fetching access report: %v\n", err)
+	}
+
+	fmt.Printf("===== Roles of %s (%s) =====\n", report.User.Entity.Username, report.User.GUID)
+	for _, role := range report.Roles {
+		if role.Role.IsSpaceRole() {
+			fmt.Printf("%s\t%s/%s\n", role.Role, role.Organization.Entity.Name, role.Space.Entity.Name)
+		} else {
+			fmt.Printf("%s\t%s\n", role.Role, role.Organization.Entity.Name)
+		}
+	}
+	fmt.Printf("===== Applications =====\n")
+	for _, app := range report.Applications {
+		fmt.Printf("%s\t%s\n", app.Entity.Name, app.GUID)
+	}
+	fmt.Printf("===== Events since %s =====\n", now.Add(-*since).Format(time.RFC3339))
+	for _, event := range report.Events {
+		fmt.Printf("%s\t%s\t%s\n", event.Entity.Timestamp.Format(time.RFC3339), event.Entity.Type, event.Entity.ActeeName)
+	}
+}
+
+// newClient creates a client authenticated with a one-time passcode, with the
+// provided username and password or, if no username is provided, with the
+// cf CLI credentials.
+func newClient(ctx context.Context) (*ccv2.Client, error) {
+	if username == "" && !sso {
+		cfg, err := auth.LoadCFConfig()
+		if err != nil {
+			return nil, err
+		}
+		return cfg.Client()
+	}
+
+	apiURL, err := url.Parse(api)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing api url: %v", err)
+	}
+	cf := &ccv2.Client{
+		API:        apiURL,
+		HTTPClient: http.DefaultClient,
+	}
+
+	discoverCtx, cancel := context.WithTimeout(ctx, time.Second*5)
+	defer cancel()
+	uaa, err := auth.Discover(discoverCtx, cf)
+	if err != nil {
+		return nil, fmt.Errorf("error discovering uaa: %v", err)
+	}
+
+	if sso {
+		doer, err := loginWithPasscode(ctx, uaa)
+		if err != nil {
+			return nil, err
+		}
+		cf.HTTPClient = doer
+		return cf, nil
+	}
+
+	tokenCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	doer, err := uaa.Password(tokenCtx, username, password)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching oauth2 token: %v", err)
+	}
+	cf.HTTPClient = doer
+	return cf, nil
+}
+
+// loginWithPasscode asks the user for a one-time passcode and exchanges it
+// for a token.
+func loginWithPasscode(ctx context.Context, uaa *auth.UAA) (*auth.Doer, error) {
+	promptCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	passcodeURL, err := uaa.PasscodeURL(promptCtx)
+	if err != nil {
+		return nil, fmt.Errorf("error discovering passcode url: %v", err)
+	}
+
+	fmt.Fprintf(os.Stderr, "Temporary Authentication Code ( Get one at %s ): ", passcodeURL)
+	passcode, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil {
+		return nil, fmt.Errorf("error reading passcode: %v", err)
+	}
+
+	tokenCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	doer, err := uaa.Passcode(tokenCtx, strings.TrimSpace(passcode))
+	if err != nil {
+		return nil, fmt.Errorf("error fetching oauth2 token: %v", err)
+	}
+	return doer, nil
+}
@@ -0,0 +1,179 @@
+package ccv2
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/pkg/errors"
+)
+
+// Sentinel errors that an UnexpectedResponseError can be matched against
+// using errors.Is.
+var (
+	// ErrNotFound indicates that the requested resource does not exist.
+	ErrNotFound = errors.New("not found")
+	// ErrUnauthorized indicates that the request lacks valid authentication.
+	ErrUnauthorized = errors.New("unauthorized")
+	// ErrForbidden indicates that the authenticated identity is not allowed
+	// to perform the request.
+	ErrForbidden = errors.New("forbidden")
+	// ErrRateLimited indicates that the request was rejected by the Cloud
+	// Controller's rate limiter.
+	ErrRateLimited = errors.New("rate limited")
+	// ErrServerError indicates that the Cloud Controller, or a component in
+	// front of it, failed to process the request.
+	ErrServerError = errors.New("server error")
+)
+
+// Well-known values of UnexpectedResponseError.ErrorCode.
+const (
+	ErrorCodeMessageParseError       = "CF-MessageParseError"
+	ErrorCodeInvalidAuthToken        = "CF-InvalidAuthToken"
+	ErrorCodeNotFound                = "CF-NotFound"
+	ErrorCodeNotAuthenticated        = "CF-NotAuthenticated"
+	ErrorCodeNotAuthorized           = "CF-NotAuthorized"
+	ErrorCodeInvalidRequest          = "CF-InvalidRequest"
+	ErrorCodeBadQueryParameter       = "CF-BadQueryParameter"
+	ErrorCodeRateLimitExceeded       = "CF-RateLimitExceeded"
+	ErrorCodeServiceUnavailable      = "CF-ServiceUnavailable"
+	ErrorCodeUserNotFound            = "CF-UserNotFound"
+	ErrorCodeOrganizationNotFound    = "CF-OrganizationNotFound"
+	ErrorCodeSpaceNotFound           = "CF-SpaceNotFound"
+	ErrorCodeServiceInstanceNotFound = "CF-ServiceInstanceNotFound"
+	ErrorCodeAppNotFound             = "CF-AppNotFound"
+	ErrorCodeDomainNotFound          = "CF-DomainNotFound"
+	ErrorCodeRouteNotFound           = "CF-RouteNotFound"
+	ErrorCodeQuotaDefinitionNotFound = "CF-QuotaDefinitionNotFound"
+	ErrorCodeServiceBindingNotFound  = "CF-ServiceBindingNotFound"
+	ErrorCodeServiceBrokerNotFound   = "CF-ServiceBrokerNotFound"
+	ErrorCodeEventNotFound           = "CF-EventNotFound"
+	ErrorCodeStagingInProgress       = "CF-StagingInProgress"
+	ErrorCodeAppStoppedStatsError    = "CF-AppStoppedStatsError"
+	ErrorCodeInsufficientScope       = "CF-InsufficientScope"
+	ErrorCodeServiceGatewayError     = "CF-ServiceGatewayError"
+)
+
+// UnexpectedResponseError wraps response that indicates error.
+type UnexpectedResponseError struct {
+	StatusCode  int    `json:"status_code"`
+	Code        int    `json:"code"`
+	Description string `json:"description"`
+	ErrorCode   string `json:"error_code"`
+
+	// Method and URL identify the request that failed.
+	Method string `json:"-"`
+	URL    string `json:"-"`
+	// RequestID is the value of the X-Vcap-Request-Id response header, which
+	// can be used to correlate the error with the Cloud Controller logs.
+	RequestID string `json:"-"`
+	// RateLimit is the rate limit status reported via the X-RateLimit-*
+	// response headers, or nil if the response did not carry them.
+	RateLimit *RateLimitStatus `json:"-"`
+
+	// ContentType is the content type of the response.
+	ContentType string `json:"-"`
+	// Body holds up to the first 4KiB of the response body. It is useful
+	// when the response does not originate from the Cloud Controller, e.g.
+	// an HTML error page returned by a load balancer.
+	Body string `json:"-"`
+	// Errors holds the errors of responses in the format used by version 3
+	// of the API. The first one is also reflected in Code, ErrorCode and
+	// Description.
+	Errors []V3Error `json:"-"`
+}
+
+// V3Error represents an error in the format used by version 3 of the API.
+type V3Error struct {
+	Code   int    `json:"code"`
+	Title  string `json:"title"`
+	Detail string `json:"detail"`
+}
+
+// Error returns a description of the error.
+func (e *UnexpectedResponseError) Error() string {
+	if e.Method == "" {
+		return e.Description
+	}
+	msg := fmt.Sprintf("%s %s: %d", e.Method, e.URL, e.StatusCode)
+	if e.ErrorCode != "" {
+		msg += " " + e.ErrorCode
+	}
+	return msg + ": " + e.Description
+}
+
+// Is reports whether the error matches target, which is one of ErrNotFound,
+// ErrUnauthorized, ErrForbidden, ErrRateLimited or ErrServerError.
+func (e *UnexpectedResponseError) Is(target error) bool {
+	switch target {
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound
+	case ErrUnauthorized:
+		return e.StatusCode == http.StatusUnauthorized
+	case ErrForbidden:
+		return e.StatusCode == http.StatusForbidden
+	case ErrRateLimited:
+		return e.StatusCode == http.StatusTooManyRequests
+	case ErrServerError:
+		return e.StatusCode >= http.StatusInternalServerError
+	}
+	return false
+}
+
+// maxErrorBodySize is the maximum number of bytes read from the body of an
+// error response.
+const maxErrorBodySize = 4 << 10
+
+func errFromResponse(resp *http.Response) error {
+	e := &UnexpectedResponseError{
+		StatusCode:  resp.StatusCode,
+		RequestID:   resp.Header.Get("X-Vcap-Request-Id"),
+		ContentType: resp.Header.Get("Content-Type"),
+	}
+	if status, ok := parseRateLimitStatus(resp.Header); ok {
+		e.RateLimit = &status
+	}
+	if resp.Request != nil {
+		e.Method = resp.Request.Method
+		e.URL = resp.Request.URL.String()
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodySize))
+	if err != nil {
+		e.Description = err.Error()
+		return e
+	}
+	e.Body = string(body)
+	if !e.decodeBody(body) {
+		e.Description = http.StatusText(resp.StatusCode)
+	}
+	return e
+}
+
+// decodeBody fills the error details from a response body in either the v2
+// or the v3 error format. It reports whether the body was in any of them.
+func (e *UnexpectedResponseError) decodeBody(body []byte) bool {
+	var payload struct {
+		Code        int       `json:"code"`
+		Description string    `json:"description"`
+		ErrorCode   string    `json:"error_code"`
+		Errors      []V3Error `json:"errors"`
+	}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return false
+	}
+	switch {
+	case payload.Description != "" || payload.ErrorCode != "":
+		e.Code = payload.Code
+		e.Description = payload.Description
+		e.ErrorCode = payload.ErrorCode
+	case len(payload.Errors) > 0:
+		e.Errors = payload.Errors
+		e.Code = payload.Errors[0].Code
+		e.Description = payload.Errors[0].Detail
+		e.ErrorCode = payload.Errors[0].Title
+	default:
+		return false
+	}
+	return true
+}
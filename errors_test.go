@@ -0,0 +1,174 @@
+package ccv2_test
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	. "github.com/Bo0mer/ccv2"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("UnexpectedResponseError", func() {
+	var client *Client
+	var server *ghttp.Server
+
+	var err error
+
+	BeforeEach(func() {
+		client, server = setupTestClientAndServer()
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	Context("when the server returns a not found response", func() {
+		JustBeforeEach(func() {
+			_, err = client.Applications(context.Background())
+		})
+
+		BeforeEach(func() {
+			server.AppendHandlers(
+				ghttp.RespondWith(http.StatusNotFound, `
+{
+    "code": 100004,
+    "description": "The app could not be found: foo",
+    "error_code": "CF-AppNotFound"
+}`, http.Header{"X-Vcap-Request-Id": []string{"request-id"}}),
+			)
+		})
+
+		It("should have returned an error matching ErrNotFound", func() {
+			Ω(errors.Is(err, ErrNotFound)).Should(BeTrue())
+			Ω(errors.Is(err, ErrServerError)).Should(BeFalse())
+		})
+
+		It("should have described the failed request", func() {
+			var e *UnexpectedResponseError
+			Ω(errors.As(err, &e)).Should(BeTrue())
+			Ω(e.Code).Should(Equal(100004))
+			Ω(e.ErrorCode).Should(Equal(ErrorCodeAppNotFound))
+			Ω(e.Method).Should(Equal("GET"))
+			Ω(e.URL).Should(Equal("http://" + server.Addr() + "/v2/apps"))
+			Ω(e.RequestID).Should(Equal("request-id"))
+			Ω(e.Error()).Should(Equal("GET " + e.URL + ": 404 CF-AppNotFound: The app could not be found: foo"))
+		})
+	})
+
+	Context("when the server rate limits the request", func() {
+		BeforeEach(func() {
+			server.AppendHandlers(
+				ghttp.RespondWith(http.StatusTooManyRequests, `
+{
+    "code": 10013,
+    "description": "Rate Limit Exceeded",
+    "error_code": "CF-RateLimitExceeded"
+}`, http.Header{
+					"X-RateLimit-Limit":     []string{"100"},
+					"X-RateLimit-Remaining": []string{"0"},
+					"X-RateLimit-Reset":     []string{"1465404083"},
+				}),
+			)
+		})
+
+		It("should have attached the rate limit status", func() {
+			_, err := client.Info(context.Background())
+			Ω(errors.Is(err, ErrRateLimited)).Should(BeTrue())
+			var e *UnexpectedResponseError
+			Ω(errors.As(err, &e)).Should(BeTrue())
+			Ω(e.RateLimit).Should(Equal(&RateLimitStatus{
+				Limit:     100,
+				Remaining: 0,
+				Reset:     time.Unix(1465404083, 0),
+			}))
+		})
+	})
+
+	Context("when a proxy returns a non-JSON response", func() {
+		BeforeEach(func() {
+			server.AppendHandlers(
+				ghttp.RespondWith(http.StatusBadGateway, "<html><body>502 Bad Gateway</body></html>", http.Header{
+					"Content-Type": []string{"text/html"},
+				}),
+			)
+		})
+
+		It("should have preserved the response body", func() {
+			_, err := client.Info(context.Background())
+			var e *UnexpectedResponseError
+			Ω(errors.As(err, &e)).Should(BeTrue())
+			Ω(e.StatusCode).Should(Equal(http.StatusBadGateway))
+			Ω(e.Description).Should(Equal("Bad Gateway"))
+			Ω(e.ContentType).Should(Equal("text/html"))
+			Ω(e.Body).Should(Equal("<html><body>502 Bad Gateway</body></html>"))
+			Ω(e.RateLimit).Should(BeNil())
+		})
+	})
+
+	Context("when the response body is large", func() {
+		BeforeEach(func() {
+			server.AppendHandlers(
+				ghttp.RespondWith(http.StatusServiceUnavailable, strings.Repeat("x", 1<<20)),
+			)
+		})
+
+		It("should have kept only a snippet of the body", func() {
+			_, err := client.Info(context.Background())
+			var e *UnexpectedResponseError
+			Ω(errors.As(err, &e)).Should(BeTrue())
+			Ω(e.Body).Should(HaveLen(4096))
+		})
+	})
+
+	Context("when the server returns a v3 error", func() {
+		BeforeEach(func() {
+			server.AppendHandlers(
+				ghttp.RespondWith(http.StatusUnprocessableEntity, `
+{
+    "errors": [
+        {
+            "code": 10008,
+            "title": "CF-UnprocessableEntity",
+            "detail": "something went wrong"
+        }
+    ]
+}`),
+			)
+		})
+
+		It("should have decoded the error", func() {
+			_, err := client.Info(context.Background())
+			var e *UnexpectedResponseError
+			Ω(errors.As(err, &e)).Should(BeTrue())
+			Ω(e.Code).Should(Equal(10008))
+			Ω(e.ErrorCode).Should(Equal("CF-UnprocessableEntity"))
+			Ω(e.Description).Should(Equal("something went wrong"))
+			Ω(e.Errors).Should(Equal([]V3Error{{
+				Code:   10008,
+				Title:  "CF-UnprocessableEntity",
+				Detail: "something went wrong",
+			}}))
+		})
+	})
+
+	DescribeTable("classification by status code",
+		func(statusCode int, target error) {
+			server.AppendHandlers(ghttp.RespondWith(statusCode, `{}`))
+			_, err := client.Info(context.Background())
+			Ω(errors.Is(err, target)).Should(BeTrue())
+		},
+		Entry("unauthorized", http.StatusUnauthorized, ErrUnauthorized),
+		Entry("forbidden", http.StatusForbidden, ErrForbidden),
+		Entry("rate limited", http.StatusTooManyRequests, ErrRateLimited),
+		Entry("internal server error", http.StatusInternalServerError, ErrServerError),
+		Entry("bad gateway", http.StatusBadGateway, ErrServerError),
+	)
+})
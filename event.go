@@ -1,21 +1,41 @@
 package ccv2
 
-import "time"
+import (
+	"context"
+	"iter"
+	"time"
+)
 
 // Event represents a Cloud Foundry application event.
-type Event struct {
-	Metadata `json:"metadata"`
+type Event = Resource[EventEntity]
 
-	Entity struct {
-		Type             string    `json:"type"`
-		Actor            string    `json:"actor"`
-		ActorType        string    `json:"actor_type"`
-		ActorName        string    `json:"actor_name"`
-		Actee            string    `json:"actee"`
-		ActeeType        string    `json:"actee_type"`
-		ActeeName        string    `json:"actee_name"`
-		Timestamp        time.Time `json:"timestamp"`
-		SpaceGUID        string    `json:"space_guid"`
-		OrganizationGUID string    `json:"organization_guid"`
-	} `json:"entity"`
+// EventEntity holds the fields of an event.
+type EventEntity struct {
+	Type             string    `json:"type"`
+	Actor            string    `json:"actor"`
+	ActorType        string    `json:"actor_type"`
+	ActorName        string    `json:"actor_name"`
+	Actee            string    `json:"actee"`
+	ActeeType        string    `json:"actee_type"`
+	ActeeName        string    `json:"actee_name"`
+	Timestamp        time.Time `json:"timestamp"`
+	SpaceGUID        string    `json:"space_guid"`
+	OrganizationGUID string    `json:"organization_guid"`
+}
+
+// Events list all events that conform to the provided queries.
+func (c *Client) Events(ctx context.Context, queries ...Query) ([]Event, error) {
+	return ListResources[EventEntity](ctx, c, "/v2/events", queries...)
+}
+
+// EachEvent returns an iterator over all events that conform to the provided
+// queries. Unlike Events, it holds at most one page in memory and stops
+// requesting pages as soon as the loop is exited.
+func (c *Client) EachEvent(ctx context.Context, queries ...Query) iter.Seq2[Event, error] {
+	return EachResource[EventEntity](ctx, c, "/v2/events", queries...)
+}
+
+// Event returns the event with the given GUID.
+func (c *Client) Event(ctx context.Context, guid string) (Event, error) {
+	return GetResource[EventEntity](ctx, c, "/v2/events", guid)
 }
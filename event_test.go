@@ -2,9 +2,12 @@ package ccv2_test
 
 import (
 	"context"
+	"fmt"
 	"net/http"
 	"time"
 
+	"github.com/pkg/errors"
+
 	. "github.com/Bo0mer/ccv2"
 
 	. "github.com/onsi/ginkgo"
@@ -77,8 +80,8 @@ var _ = Describe("Events", func() {
 			Ω(events).Should(HaveLen(1))
 			event := events[0]
 			Ω(event.GUID).Should(Equal("b8ede8e1-afc8-40a1-baae-236a0a77b27b"))
-			Ω(event.CreatedAt).Should(Equal("2016-06-08T16:41:23Z"))
-			Ω(event.UpdatedAt).Should(Equal("2016-06-08T16:41:26Z"))
+			Ω(event.CreatedAt).Should(Equal(parseTime("2016-06-08T16:41:23Z")))
+			Ω(event.UpdatedAt).Should(Equal(parseTime("2016-06-08T16:41:26Z")))
 			Ω(event.Entity.Actee).Should(Equal("guid-e7790fa4-be2b-4a0f-aa82-c124342b0bb4"))
 			Ω(event.Entity.ActeeName).Should(Equal("name-171"))
 			Ω(event.Entity.ActeeType).Should(Equal("name-170"))
@@ -96,4 +99,141 @@ var _ = Describe("Events", func() {
 		})
 	})
 
+	Context("when concurrent pagination is enabled", func() {
+		BeforeEach(func() {
+			client.PageConcurrency = 2
+			queries = []Query{{Filter: FilterType, Op: OperatorEqual, Value: "app.crash"}}
+
+			server.RouteToHandler("GET", "/v2/events", func(w http.ResponseWriter, r *http.Request) {
+				page := r.URL.Query().Get("page")
+				if page == "" {
+					page = "1"
+				}
+				var e Event
+				e.GUID = "event-" + page
+				resp := eventPaginatedResponse{
+					TotalPages: 4,
+					Resources:  []Event{e},
+				}
+				if page == "1" {
+					resp.NextURL = "/v2/events?page=2&q=type%3Aapp.crash"
+				}
+				ghttp.RespondWithJSONEncoded(http.StatusOK, resp)(w, r)
+			})
+		})
+
+		It("should have returned all events in order", func() {
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(events).Should(HaveLen(4))
+			for i, event := range events {
+				Ω(event.GUID).Should(Equal(fmt.Sprintf("event-%d", i+1)))
+			}
+			Ω(server.ReceivedRequests()).Should(HaveLen(4))
+			for _, r := range server.ReceivedRequests() {
+				Ω(r.URL.Query()["q"]).Should(Equal([]string{"type:app.crash"}))
+			}
+		})
+
+		It("should have fetched pages sequentially when iterating", func() {
+			sent := len(server.ReceivedRequests())
+			var guids []string
+			for event, err := range client.EachEvent(context.Background(), queries...) {
+				Ω(err).ShouldNot(HaveOccurred())
+				guids = append(guids, event.GUID)
+				if len(guids) == 2 {
+					break
+				}
+			}
+			Ω(guids).Should(Equal([]string{"event-1", "event-2"}))
+			Ω(server.ReceivedRequests()).Should(HaveLen(sent + 2))
+		})
+	})
+
+	Context("when concurrent pagination is enabled and a page fails", func() {
+		BeforeEach(func() {
+			client.PageConcurrency = 3
+
+			server.RouteToHandler("GET", "/v2/events", func(w http.ResponseWriter, r *http.Request) {
+				switch r.URL.Query().Get("page") {
+				case "":
+					ghttp.RespondWithJSONEncoded(http.StatusOK, eventPaginatedResponse{
+						TotalPages: 3,
+						NextURL:    "/v2/events?page=2",
+					})(w, r)
+				case "2":
+					notFoundHandler()(w, r)
+				default:
+					ghttp.RespondWithJSONEncoded(http.StatusOK, eventPaginatedResponse{
+						TotalPages: 3,
+					})(w, r)
+				}
+			})
+		})
+
+		It("should have returned the error", func() {
+			Ω(err).Should(beNotFoundErr())
+		})
+	})
 })
+
+var _ = Describe("Event", func() {
+	var client *Client
+	var server *ghttp.Server
+
+	var event Event
+	var err error
+
+	BeforeEach(func() {
+		client, server = setupTestClientAndServer()
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	JustBeforeEach(func() {
+		event, err = client.Event(context.Background(), "b8ede8e1-afc8-40a1-baae-236a0a77b27b")
+	})
+
+	Context("when the server returns a valid response", func() {
+		BeforeEach(func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/v2/events/b8ede8e1-afc8-40a1-baae-236a0a77b27b"),
+					ghttp.RespondWith(http.StatusOK, `
+{
+    "metadata": {
+        "guid": "b8ede8e1-afc8-40a1-baae-236a0a77b27b"
+    },
+    "entity": {
+        "type": "app.crash"
+    }
+}`),
+				),
+			)
+		})
+
+		It("should have returned the event", func() {
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(event.GUID).Should(Equal("b8ede8e1-afc8-40a1-baae-236a0a77b27b"))
+			Ω(event.Entity.Type).Should(Equal("app.crash"))
+		})
+	})
+
+	Context("when the event does not exist", func() {
+		BeforeEach(func() {
+			server.AppendHandlers(notFoundHandler())
+		})
+
+		It("should have returned an error matching ErrNotFound", func() {
+			Ω(err).Should(beNotFoundErr())
+			Ω(errors.Is(err, ErrNotFound)).Should(BeTrue())
+		})
+	})
+})
+
+type eventPaginatedResponse struct {
+	TotalPages int     `json:"total_pages"`
+	NextURL    string  `json:"next_url"`
+	Resources  []Event `json:"resources"`
+}
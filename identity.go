@@ -0,0 +1,124 @@
+package ccv2
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Cloud Controller scopes of UAA tokens.
+const (
+	// ScopeAdmin grants full access to all resources.
+	ScopeAdmin = "cloud_controller.admin"
+	// ScopeAdminReadOnly grants read access to all resources.
+	ScopeAdminReadOnly = "cloud_controller.admin_read_only"
+	// ScopeGlobalAuditor grants read access to all resources, except
+	// secrets such as environment variables.
+	ScopeGlobalAuditor = "cloud_controller.global_auditor"
+	// ScopeRead grants read access to the resources the user has roles on.
+	ScopeRead = "cloud_controller.read"
+	// ScopeWrite grants write access to the resources the user has roles on.
+	ScopeWrite = "cloud_controller.write"
+)
+
+// Identity represents the identity described by a UAA access token.
+type Identity struct {
+	// UserName and UserID identify the user the token was issued to. They
+	// are empty for tokens obtained using the client credentials grant.
+	UserName string `json:"user_name"`
+	UserID   string `json:"user_id"`
+	// ClientID is the UAA client that obtained the token.
+	ClientID string `json:"client_id"`
+	// Scopes are the scopes granted to the token.
+	Scopes []string `json:"scope"`
+	// Expiry is the time at which the token expires.
+	Expiry time.Time `json:"-"`
+}
+
+// HasScope reports whether the identity has been granted scope.
+func (i Identity) HasScope(scope string) bool {
+	for _, s := range i.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseToken decodes the identity from a UAA access token. The signature of
+// the token is not verified.
+func ParseToken(accessToken string) (Identity, error) {
+	parts := strings.Split(accessToken, ".")
+	if len(parts) != 3 {
+		return Identity{}, errors.New("token is not a JWT")
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(parts[1], "="))
+	if err != nil {
+		return Identity{}, errors.Wrap(err, "decoding token payload failed")
+	}
+	var claims struct {
+		Identity
+		Expiry int64 `json:"exp"`
+	}
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return Identity{}, errors.Wrap(err, "unmarshaling token claims failed")
+	}
+	identity := claims.Identity
+	if claims.Expiry != 0 {
+		identity.Expiry = time.Unix(claims.Expiry, 0)
+	}
+	return identity, nil
+}
+
+// Visibility describes which part of the foundation an identity can see.
+type Visibility string
+
+const (
+	// VisibilityGlobal means that all resources of the foundation are
+	// visible, e.g. to admins and global auditors.
+	VisibilityGlobal Visibility = "global"
+	// VisibilityRestricted means that only resources to which the identity
+	// has been granted access via organization and space roles are visible.
+	VisibilityRestricted Visibility = "restricted"
+)
+
+// AccessTokenSource provides the access token with which requests are
+// authenticated.
+type AccessTokenSource interface {
+	AccessToken() (string, error)
+}
+
+// Identity returns the identity on whose behalf the client sends requests.
+// The HTTPClient of the client must implement AccessTokenSource, as the
+// Doers of package github.com/Bo0mer/ccv2/auth do.
+func (c *Client) Identity() (Identity, error) {
+	ts, ok := c.HTTPClient.(AccessTokenSource)
+	if !ok {
+		return Identity{}, errors.New("http client does not provide tokens")
+	}
+	token, err := ts.AccessToken()
+	if err != nil {
+		return Identity{}, errors.Wrap(err, "obtaining token failed")
+	}
+	return ParseToken(token)
+}
+
+// Visibility reports whether the identity of the client sees the whole
+// foundation or only the resources it has been granted access to. List
+// methods such as Organizations silently omit resources that are not
+// visible to the identity.
+func (c *Client) Visibility() (Visibility, error) {
+	identity, err := c.Identity()
+	if err != nil {
+		return "", err
+	}
+	if identity.HasScope(ScopeAdmin) ||
+		identity.HasScope(ScopeAdminReadOnly) ||
+		identity.HasScope(ScopeGlobalAuditor) {
+		return VisibilityGlobal, nil
+	}
+	return VisibilityRestricted, nil
+}
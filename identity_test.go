@@ -0,0 +1,94 @@
+package ccv2_test
+
+import (
+	"encoding/base64"
+	"net/http"
+	"time"
+
+	. "github.com/Bo0mer/ccv2"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+type tokenDoer struct {
+	*http.Client
+	token string
+}
+
+func (d *tokenDoer) AccessToken() (string, error) {
+	return d.token, nil
+}
+
+func fakeJWT(claims string) string {
+	enc := base64.RawURLEncoding
+	return enc.EncodeToString([]byte(`{"alg":"none"}`)) + "." +
+		enc.EncodeToString([]byte(claims)) + ".signature"
+}
+
+var _ = Describe("Identity", func() {
+	var client *Client
+	var claims string
+
+	BeforeEach(func() {
+		client = &Client{}
+	})
+
+	JustBeforeEach(func() {
+		client.HTTPClient = &tokenDoer{
+			Client: http.DefaultClient,
+			token:  fakeJWT(claims),
+		}
+	})
+
+	Context("when the token belongs to a regular user", func() {
+		BeforeEach(func() {
+			claims = `{
+    "user_name": "jdoe",
+    "user_id": "5f1a6b4c-0d3e-4b1c-9b7a-2d3c4e5f6a7b",
+    "client_id": "cf",
+    "scope": ["openid", "cloud_controller.read", "cloud_controller.write"],
+    "exp": 1476892800
+}`
+		})
+
+		It("should have decoded the identity", func() {
+			identity, err := client.Identity()
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(identity.UserName).Should(Equal("jdoe"))
+			Ω(identity.UserID).Should(Equal("5f1a6b4c-0d3e-4b1c-9b7a-2d3c4e5f6a7b"))
+			Ω(identity.ClientID).Should(Equal("cf"))
+			Ω(identity.Scopes).Should(ConsistOf("openid", ScopeRead, ScopeWrite))
+			Ω(identity.Expiry).Should(Equal(time.Unix(1476892800, 0)))
+		})
+
+		It("should have reported restricted visibility", func() {
+			visibility, err := client.Visibility()
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(visibility).Should(Equal(VisibilityRestricted))
+		})
+	})
+
+	Context("when the token belongs to a global auditor", func() {
+		BeforeEach(func() {
+			claims = `{"user_name": "auditor", "scope": ["cloud_controller.global_auditor"]}`
+		})
+
+		It("should have reported global visibility", func() {
+			visibility, err := client.Visibility()
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(visibility).Should(Equal(VisibilityGlobal))
+		})
+	})
+
+	Context("when the http client does not provide tokens", func() {
+		JustBeforeEach(func() {
+			client.HTTPClient = http.DefaultClient
+		})
+
+		It("should have returned an error", func() {
+			_, err := client.Visibility()
+			Ω(err).Should(HaveOccurred())
+		})
+	})
+})
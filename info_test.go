@@ -80,7 +80,7 @@ var _ = Describe("Info", func() {
 		})
 
 		It("should have returned a UnexpectedResponseError", func() {
-			Ω(err).Should(Equal(notFoundErr))
+			Ω(err).Should(beNotFoundErr())
 		})
 	})
 
@@ -0,0 +1,111 @@
+package ccv2
+
+import (
+	"net/url"
+	"strconv"
+
+	"github.com/pkg/errors"
+)
+
+// OrderDirection specifies the order in which listed resources are returned.
+type OrderDirection string
+
+const (
+	// OrderAscending orders resources in ascending order.
+	OrderAscending OrderDirection = "asc"
+	// OrderDescending orders resources in descending order.
+	OrderDescending OrderDirection = "desc"
+)
+
+// ListOptions controls how the Cloud Controller pages, orders and expands
+// listed resources. The zero value of each field leaves the corresponding
+// Cloud Controller default in place.
+type ListOptions struct {
+	// ResultsPerPage is the number of resources per page, between 1 and 100.
+	ResultsPerPage int
+	// OrderDirection is the direction in which resources are ordered.
+	OrderDirection OrderDirection
+	// OrderBy is the field by which resources are ordered, e.g. timestamp.
+	OrderBy string
+	// InlineRelationsDepth is the depth up to which related resources are
+	// included in the listed resources, between 0 and 3.
+	InlineRelationsDepth int
+}
+
+// WithOptions returns a query that makes the list method it is passed to
+// apply opts. Unlike other queries, it is not sent as a q parameter and it
+// does not filter the listed resources.
+//
+// For example, the newest 50 events can be listed with:
+//
+//	var events []ccv2.Event
+//	opts := ccv2.WithOptions(ccv2.ListOptions{
+//		ResultsPerPage: 50,
+//		OrderDirection: ccv2.OrderDescending,
+//		OrderBy:        "timestamp",
+//	})
+//	for event, err := range cf.EachEvent(ctx, opts) {
+//		if err != nil {
+//			return err
+//		}
+//		events = append(events, event)
+//		if len(events) == 50 {
+//			break
+//		}
+//	}
+//
+// Events, in contrast, would follow next_url and return the events of every
+// page, 50 per request.
+func WithOptions(opts ListOptions) Query {
+	return Query{options: &opts}
+}
+
+// params returns the query parameters that represent the options.
+func (o ListOptions) params() (url.Values, error) {
+	params := url.Values{}
+	if o.ResultsPerPage != 0 {
+		if o.ResultsPerPage < 1 || o.ResultsPerPage > 100 {
+			return nil, errors.Errorf("results per page %d not between 1 and 100", o.ResultsPerPage)
+		}
+		params.Set("results-per-page", strconv.Itoa(o.ResultsPerPage))
+	}
+	switch o.OrderDirection {
+	case "":
+	case OrderAscending, OrderDescending:
+		params.Set("order-direction", string(o.OrderDirection))
+	default:
+		return nil, errors.Errorf("unknown order direction %q", o.OrderDirection)
+	}
+	if o.OrderBy != "" {
+		params.Set("order-by", o.OrderBy)
+	}
+	if o.InlineRelationsDepth != 0 {
+		if o.InlineRelationsDepth < 0 || o.InlineRelationsDepth > 3 {
+			return nil, errors.Errorf("inline relations depth %d not between 0 and 3", o.InlineRelationsDepth)
+		}
+		params.Set("inline-relations-depth", strconv.Itoa(o.InlineRelationsDepth))
+	}
+	return params, nil
+}
+
+// splitQueries separates the queries carrying list options from the ones
+// filtering resources, and returns the query parameters that represent the
+// list options. Later options take precedence over earlier ones.
+func splitQueries(queries []Query) ([]Query, url.Values, error) {
+	var filters []Query
+	params := url.Values{}
+	for _, q := range queries {
+		if q.options == nil {
+			filters = append(filters, q)
+			continue
+		}
+		p, err := q.options.params()
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "invalid list options")
+		}
+		for key, values := range p {
+			params[key] = values
+		}
+	}
+	return filters, params, nil
+}
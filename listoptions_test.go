@@ -0,0 +1,184 @@
+package ccv2_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	. "github.com/Bo0mer/ccv2"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("ListOptions", func() {
+	var client *Client
+	var server *ghttp.Server
+
+	var opts ListOptions
+	var events []Event
+	var err error
+
+	BeforeEach(func() {
+		client, server = setupTestClientAndServer()
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	JustBeforeEach(func() {
+		events, err = client.Events(context.Background(), Eq(FilterType, "app.crash"), WithOptions(opts))
+	})
+
+	Context("when the options are valid", func() {
+		BeforeEach(func() {
+			opts = ListOptions{
+				ResultsPerPage:       50,
+				OrderDirection:       OrderDescending,
+				OrderBy:              "timestamp",
+				InlineRelationsDepth: 1,
+			}
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/v2/events",
+						"inline-relations-depth=1&order-by=timestamp&order-direction=desc&q=type%3Aapp.crash&results-per-page=50"),
+					ghttp.RespondWith(http.StatusOK, `
+{
+    "next_url": "/v2/events?order-direction=desc&page=2&q=type%3Aapp.crash&results-per-page=50",
+    "resources": [{"metadata": {"guid": "event-1"}}]
+}`),
+				),
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/v2/events",
+						"inline-relations-depth=1&order-by=timestamp&order-direction=desc&page=2&q=type%3Aapp.crash&results-per-page=50"),
+					ghttp.RespondWith(http.StatusOK, `{"resources": [{"metadata": {"guid": "event-2"}}]}`),
+				),
+			)
+		})
+
+		It("should have applied the options to every page", func() {
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(events).Should(HaveLen(2))
+			Ω(events[0].GUID).Should(Equal("event-1"))
+			Ω(events[1].GUID).Should(Equal("event-2"))
+		})
+	})
+
+	Context("when the options are passed to another call", func() {
+		BeforeEach(func() {
+			opts = ListOptions{OrderBy: "timestamp"}
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/v2/events", "order-by=timestamp&q=type%3Aapp.crash"),
+					ghttp.RespondWith(http.StatusOK, `{"resources": []}`),
+				),
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/v2/organizations", ""),
+					ghttp.RespondWith(http.StatusOK, `{"resources": []}`),
+				),
+			)
+		})
+
+		It("should have not applied them to subsequent calls", func() {
+			Ω(err).ShouldNot(HaveOccurred())
+			_, err := client.Organizations(context.Background())
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(server.ReceivedRequests()).Should(HaveLen(2))
+		})
+	})
+
+	Context("when the options are passed to Get", func() {
+		BeforeEach(func() {
+			opts = ListOptions{}
+			server.AppendHandlers(
+				ghttp.RespondWith(http.StatusOK, `{"resources": []}`),
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/v2/apps/app-guid", "inline-relations-depth=2"),
+					ghttp.RespondWith(http.StatusOK, `{"metadata": {"guid": "app-guid"}}`),
+				),
+			)
+		})
+
+		It("should have sent them as parameters", func() {
+			var app Application
+			err := client.Get(context.Background(), "/v2/apps/app-guid", []Query{
+				WithOptions(ListOptions{InlineRelationsDepth: 2}),
+			}, &app)
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(app.GUID).Should(Equal("app-guid"))
+		})
+	})
+
+	Context("when the number of results per page is out of range", func() {
+		BeforeEach(func() {
+			opts = ListOptions{ResultsPerPage: 101}
+		})
+
+		It("should have returned an error without sending a request", func() {
+			Ω(err).Should(MatchError(ContainSubstring("results per page 101 not between 1 and 100")))
+			Ω(server.ReceivedRequests()).Should(BeEmpty())
+		})
+	})
+
+	Context("when the order direction is unknown", func() {
+		BeforeEach(func() {
+			opts = ListOptions{OrderDirection: "sideways"}
+		})
+
+		It("should have returned an error without sending a request", func() {
+			Ω(err).Should(MatchError(ContainSubstring(`unknown order direction "sideways"`)))
+			Ω(server.ReceivedRequests()).Should(BeEmpty())
+		})
+	})
+})
+
+var _ = Describe("Listing the newest events", func() {
+	var client *Client
+	var server *ghttp.Server
+
+	BeforeEach(func() {
+		client, server = setupTestClientAndServer()
+
+		var resources []string
+		for i := 1; i <= 50; i++ {
+			resources = append(resources, fmt.Sprintf(`{"metadata": {"guid": "event-%d"}}`, i))
+		}
+		server.AppendHandlers(
+			ghttp.CombineHandlers(
+				ghttp.VerifyRequest("GET", "/v2/events", "order-by=timestamp&order-direction=desc&results-per-page=50"),
+				ghttp.RespondWith(http.StatusOK, fmt.Sprintf(`
+{
+    "total_pages": 2,
+    "next_url": "/v2/events?order-by=timestamp&order-direction=desc&page=2&results-per-page=50",
+    "resources": [%s]
+}`, strings.Join(resources, ","))),
+			),
+		)
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("should have requested only the first page", func() {
+		var events []Event
+		opts := WithOptions(ListOptions{
+			ResultsPerPage: 50,
+			OrderDirection: OrderDescending,
+			OrderBy:        "timestamp",
+		})
+		for event, err := range client.EachEvent(context.Background(), opts) {
+			Ω(err).ShouldNot(HaveOccurred())
+			events = append(events, event)
+			if len(events) == 50 {
+				break
+			}
+		}
+		Ω(events).Should(HaveLen(50))
+		Ω(events[0].GUID).Should(Equal("event-1"))
+		Ω(server.ReceivedRequests()).Should(HaveLen(1))
+	})
+})
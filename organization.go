@@ -1,13 +1,34 @@
 package ccv2
 
+import (
+	"context"
+	"iter"
+)
+
 // Organization represents a Cloud Foundry organization.
-type Organization struct {
-	Metadata `json:"metadata"`
-
-	Entity struct {
-		Name               string `json:"name"`
-		BillingEnabled     bool   `json:"billing_enabled"`
-		QuotaDefinitonGUID string `json:"quota_definiton_guid"`
-		Status             string `json:"status"`
-	} `json:"entity"`
+type Organization = Resource[OrganizationEntity]
+
+// OrganizationEntity holds the fields of an organization.
+type OrganizationEntity struct {
+	Name                string `json:"name"`
+	BillingEnabled      bool   `json:"billing_enabled"`
+	QuotaDefinitionGUID string `json:"quota_definition_guid"`
+	Status              string `json:"status"`
+}
+
+// Organizations list all organizations that conform to the provided queries.
+func (c *Client) Organizations(ctx context.Context, queries ...Query) ([]Organization, error) {
+	return ListResources[OrganizationEntity](ctx, c, "/v2/organizations", queries...)
+}
+
+// EachOrganization returns an iterator over all organizations that conform to
+// the provided queries. Unlike Organizations, it holds at most one page in
+// memory and stops requesting pages as soon as the loop is exited.
+func (c *Client) EachOrganization(ctx context.Context, queries ...Query) iter.Seq2[Organization, error] {
+	return EachResource[OrganizationEntity](ctx, c, "/v2/organizations", queries...)
+}
+
+// Organization returns the organization with the given GUID.
+func (c *Client) Organization(ctx context.Context, guid string) (Organization, error) {
+	return GetResource[OrganizationEntity](ctx, c, "/v2/organizations", guid)
 }
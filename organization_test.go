@@ -4,6 +4,8 @@ import (
 	"context"
 	"net/http"
 
+	"github.com/pkg/errors"
+
 	. "github.com/Bo0mer/ccv2"
 
 	. "github.com/onsi/ginkgo"
@@ -71,11 +73,12 @@ var _ = Describe("Organizations", func() {
 			Ω(organizations).Should(HaveLen(1))
 			org := organizations[0]
 			Ω(org.GUID).Should(Equal("a7aff246-5f5b-4cf8-87d8-f316053e4a20"))
-			Ω(org.CreatedAt).Should(Equal("2016-06-08T16:41:33Z"))
-			Ω(org.UpdatedAt).Should(Equal("2016-06-08T16:41:37Z"))
+			Ω(org.CreatedAt).Should(Equal(parseTime("2016-06-08T16:41:33Z")))
+			Ω(org.UpdatedAt).Should(Equal(parseTime("2016-06-08T16:41:37Z")))
 			Ω(org.Entity.Name).Should(Equal("NASA"))
 			Ω(org.Entity.BillingEnabled).Should(BeTrue())
 			Ω(org.Entity.Status).Should(Equal("active"))
+			Ω(org.Entity.QuotaDefinitionGUID).Should(Equal("dcb680a9-b190-4838-a3d2-b84aa17517a6"))
 		})
 
 		It("should have not returned an error", func() {
@@ -89,7 +92,7 @@ var _ = Describe("Organizations", func() {
 		})
 
 		It("should have returned a UnexpectedResponseError", func() {
-			Ω(err).Should(Equal(notFoundErr))
+			Ω(err).Should(beNotFoundErr())
 		})
 	})
 
@@ -126,6 +129,151 @@ var _ = Describe("Organizations", func() {
 	})
 })
 
+var _ = Describe("EachOrganization", func() {
+	var client *Client
+	var server *ghttp.Server
+
+	var limit int
+	var organizations []Organization
+	var err error
+
+	BeforeEach(func() {
+		client, server = setupTestClientAndServer()
+		limit = -1
+		organizations = nil
+		err = nil
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	JustBeforeEach(func() {
+		for org, ierr := range client.EachOrganization(context.Background()) {
+			if ierr != nil {
+				err = ierr
+				break
+			}
+			organizations = append(organizations, org)
+			if len(organizations) == limit {
+				break
+			}
+		}
+	})
+
+	Context("when the response is paginated", func() {
+		BeforeEach(func() {
+			var org1, org2, org3 Organization
+			org1.Metadata.GUID = "org-1"
+			org2.Metadata.GUID = "org-2"
+			org3.Metadata.GUID = "org-3"
+			p1 := orgPaginatedResponse{
+				NextURL:   "/v2/organizations?page=2",
+				Resources: []Organization{org1, org2},
+			}
+			p2 := orgPaginatedResponse{
+				NextURL:   "",
+				Resources: []Organization{org3},
+			}
+
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/v2/organizations"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, p1)),
+
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/v2/organizations", "page=2"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, p2)))
+		})
+
+		It("should have yielded all organizations", func() {
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(organizations).Should(HaveLen(3))
+			Ω(organizations[0].GUID).Should(Equal("org-1"))
+			Ω(organizations[1].GUID).Should(Equal("org-2"))
+			Ω(organizations[2].GUID).Should(Equal("org-3"))
+		})
+
+		Context("and the loop is exited early", func() {
+			BeforeEach(func() {
+				limit = 2
+			})
+
+			It("should have not requested further pages", func() {
+				Ω(organizations).Should(HaveLen(2))
+				Ω(server.ReceivedRequests()).Should(HaveLen(1))
+			})
+		})
+	})
+
+	Context("when the server returns a non-2XX response", func() {
+		BeforeEach(func() {
+			server.AppendHandlers(notFoundHandler())
+		})
+
+		It("should have yielded a UnexpectedResponseError", func() {
+			Ω(organizations).Should(BeEmpty())
+			Ω(err).Should(beNotFoundErr())
+		})
+	})
+})
+
+var _ = Describe("Organization", func() {
+	var client *Client
+	var server *ghttp.Server
+
+	var org Organization
+	var err error
+
+	BeforeEach(func() {
+		client, server = setupTestClientAndServer()
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	JustBeforeEach(func() {
+		org, err = client.Organization(context.Background(), "a7aff246-5f5b-4cf8-87d8-f316053e4a20")
+	})
+
+	Context("when the server returns a valid response", func() {
+		BeforeEach(func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/v2/organizations/a7aff246-5f5b-4cf8-87d8-f316053e4a20"),
+					ghttp.RespondWith(http.StatusOK, `
+{
+    "metadata": {
+        "guid": "a7aff246-5f5b-4cf8-87d8-f316053e4a20"
+    },
+    "entity": {
+        "name": "NASA"
+    }
+}`),
+				),
+			)
+		})
+
+		It("should have returned the organization", func() {
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(org.GUID).Should(Equal("a7aff246-5f5b-4cf8-87d8-f316053e4a20"))
+			Ω(org.Entity.Name).Should(Equal("NASA"))
+		})
+	})
+
+	Context("when the organization does not exist", func() {
+		BeforeEach(func() {
+			server.AppendHandlers(notFoundHandler())
+		})
+
+		It("should have returned an error matching ErrNotFound", func() {
+			Ω(err).Should(beNotFoundErr())
+			Ω(errors.Is(err, ErrNotFound)).Should(BeTrue())
+		})
+	})
+})
+
 type orgPaginatedResponse struct {
 	NextURL   string         `json:"next_url"`
 	Resources []Organization `json:"resources"`
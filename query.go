@@ -0,0 +1,452 @@
+package ccv2
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Filter specifies the target of a query.
+type Filter string
+
+const (
+	// FilterName specifies that the query should filter on the name field.
+	FilterName Filter = "name"
+	// FilterOrganizationGUID specifies that the query should filter on the
+	// organization_guid field.
+	FilterOrganizationGUID Filter = "organization_guid"
+	// FilterSpaceGUID specifies that the query should filter on the space_guid
+	// field.
+	FilterSpaceGUID Filter = "space_guid"
+	// FilterTimestamp specifies that the query should filter on the timestamp
+	// field.
+	FilterTimestamp Filter = "timestamp"
+	// FilterActee specifies that the query should filter on the actee field.
+	FilterActee Filter = "actee"
+	// FilterActor specifies that the query should filter on the actor field.
+	FilterActor Filter = "actor"
+	// FilterType specifies that the query should filter on the type field.
+	FilterType Filter = "type"
+	// FilterStackGUID specifies that the query should filter on the stack_guid
+	// field.
+	FilterStackGUID Filter = "stack_guid"
+	// FilterDiego specifies that the query should filter on the diego field.
+	FilterDiego Filter = "diego"
+	// FilterStatus specifies that the query should filter on the status field.
+	FilterStatus Filter = "status"
+	// FilterUserGUID specifies that the query should filter on the user_guid
+	// field.
+	FilterUserGUID Filter = "user_guid"
+	// FilterManagerGUID specifies that the query should filter on the
+	// manager_guid field.
+	FilterManagerGUID Filter = "manager_guid"
+	// FilterBillingManagerGUID specifies that the query should filter on the
+	// billing_manager_guid field.
+	FilterBillingManagerGUID Filter = "billing_manager_guid"
+	// FilterAuditorGUID specifies that the query should filter on the
+	// auditor_guid field.
+	FilterAuditorGUID Filter = "auditor_guid"
+	// FilterDeveloperGUID specifies that the query should filter on the
+	// developer_guid field.
+	FilterDeveloperGUID Filter = "developer_guid"
+	// FilterAppGUID specifies that the query should filter on the app_guid
+	// field.
+	FilterAppGUID Filter = "app_guid"
+	// FilterIsolationSegmentGUID specifies that the query should filter on the
+	// isolation_segment_guid field.
+	FilterIsolationSegmentGUID Filter = "isolation_segment_guid"
+	// FilterHost specifies that the query should filter on the host field.
+	FilterHost Filter = "host"
+	// FilterDomainGUID specifies that the query should filter on the domain_guid
+	// field.
+	FilterDomainGUID Filter = "domain_guid"
+	// FilterPath specifies that the query should filter on the path field.
+	FilterPath Filter = "path"
+	// FilterPort specifies that the query should filter on the port field.
+	FilterPort Filter = "port"
+	// FilterOwningOrganizationGUID specifies that the query should filter on the
+	// owning_organization_guid field.
+	FilterOwningOrganizationGUID Filter = "owning_organization_guid"
+	// FilterRouteGUID specifies that the query should filter on the route_guid
+	// field.
+	FilterRouteGUID Filter = "route_guid"
+	// FilterServiceGUID specifies that the query should filter on the
+	// service_guid field.
+	FilterServiceGUID Filter = "service_guid"
+	// FilterServicePlanGUID specifies that the query should filter on the
+	// service_plan_guid field.
+	FilterServicePlanGUID Filter = "service_plan_guid"
+	// FilterServiceInstanceGUID specifies that the query should filter on the
+	// service_instance_guid field.
+	FilterServiceInstanceGUID Filter = "service_instance_guid"
+	// FilterServiceBindingGUID specifies that the query should filter on the
+	// service_binding_guid field.
+	FilterServiceBindingGUID Filter = "service_binding_guid"
+	// FilterServiceKeyGUID specifies that the query should filter on the
+	// service_key_guid field.
+	FilterServiceKeyGUID Filter = "service_key_guid"
+	// FilterServiceBrokerGUID specifies that the query should filter on the
+	// service_broker_guid field.
+	FilterServiceBrokerGUID Filter = "service_broker_guid"
+	// FilterGatewayName specifies that the query should filter on the
+	// gateway_name field.
+	FilterGatewayName Filter = "gateway_name"
+	// FilterLabel specifies that the query should filter on the label field.
+	FilterLabel Filter = "label"
+	// FilterProvider specifies that the query should filter on the provider
+	// field.
+	FilterProvider Filter = "provider"
+	// FilterActive specifies that the query should filter on the active field.
+	FilterActive Filter = "active"
+	// FilterUniqueID specifies that the query should filter on the unique_id
+	// field.
+	FilterUniqueID Filter = "unique_id"
+	// FilterManagedOrganizationGUID specifies that the query should filter on
+	// the managed_organization_guid field.
+	FilterManagedOrganizationGUID Filter = "managed_organization_guid"
+	// FilterBillingManagedOrganizationGUID specifies that the query should
+	// filter on the billing_managed_organization_guid field.
+	FilterBillingManagedOrganizationGUID Filter = "billing_managed_organization_guid"
+	// FilterAuditedOrganizationGUID specifies that the query should filter on
+	// the audited_organization_guid field.
+	FilterAuditedOrganizationGUID Filter = "audited_organization_guid"
+	// FilterManagedSpaceGUID specifies that the query should filter on the
+	// managed_space_guid field.
+	FilterManagedSpaceGUID Filter = "managed_space_guid"
+	// FilterAuditedSpaceGUID specifies that the query should filter on the
+	// audited_space_guid field.
+	FilterAuditedSpaceGUID Filter = "audited_space_guid"
+)
+
+// Operator specifies an operator for a query.
+type Operator string
+
+const (
+	// OperatorEqual specifies that the result should match the value.
+	OperatorEqual Operator = ":"
+	// OperatorGreater specifies that the result should be greater than the value.
+	OperatorGreater Operator = ">"
+	// OperatorLess specifies that the result should be less than the value.
+	OperatorLess Operator = "<"
+	// OperatorGreaterOrEqual specifies that the result should be greater than
+	// or equal to the value.
+	OperatorGreaterOrEqual Operator = ">="
+	// OperatorLessOrEqual specifies that the result should be less than or
+	// equal to the value.
+	OperatorLessOrEqual Operator = "<="
+	// OperatorIn specifies that the result should match any of the values.
+	OperatorIn Operator = " IN "
+)
+
+// Query gives means to filter list of resources.
+type Query struct {
+	// Filter is the field on which the query will act.
+	Filter Filter
+	// Op is the operator that will be applied during filtering.
+	Op Operator
+	// Value is the value that will be used when applying the Op. For
+	// OperatorIn, it is the comma separated list of values.
+	Value string
+
+	// invalid is a value passed to In that cannot be represented, as it
+	// contains a comma.
+	invalid string
+	// options are set for queries created with WithOptions.
+	options *ListOptions
+}
+
+// Eq returns a query matching resources whose filter field equals value.
+func Eq(filter Filter, value string) Query {
+	return Query{Filter: filter, Op: OperatorEqual, Value: value}
+}
+
+// Gt returns a query matching resources whose filter field is greater than
+// value.
+func Gt(filter Filter, value string) Query {
+	return Query{Filter: filter, Op: OperatorGreater, Value: value}
+}
+
+// Ge returns a query matching resources whose filter field is greater than
+// or equal to value.
+func Ge(filter Filter, value string) Query {
+	return Query{Filter: filter, Op: OperatorGreaterOrEqual, Value: value}
+}
+
+// Lt returns a query matching resources whose filter field is less than
+// value.
+func Lt(filter Filter, value string) Query {
+	return Query{Filter: filter, Op: OperatorLess, Value: value}
+}
+
+// Le returns a query matching resources whose filter field is less than or
+// equal to value.
+func Le(filter Filter, value string) Query {
+	return Query{Filter: filter, Op: OperatorLessOrEqual, Value: value}
+}
+
+// In returns a query matching resources whose filter field equals any of
+// values.
+func In(filter Filter, values ...string) Query {
+	q := Query{Filter: filter, Op: OperatorIn, Value: strings.Join(values, ",")}
+	for _, v := range values {
+		if strings.ContainsRune(v, ',') {
+			q.invalid = v
+			break
+		}
+	}
+	return q
+}
+
+// String returns the string representation of the query.
+// It is of the form <Filter><Op><Value>.
+// It must be query encoded if to be used as a query param.
+func (q Query) String() string {
+	return string(q.Filter) + string(q.Op) + q.Value
+}
+
+// validate checks whether the query can be represented unambiguously. The
+// Cloud Controller treats semicolons as query separators and commas as
+// value separators of OperatorIn, and it does not support escaping them.
+func (q Query) validate() error {
+	if q.Filter == "" {
+		return errors.New("query filter is empty")
+	}
+	if !containsOperator(operators, q.Op) {
+		return errors.Errorf("operator %q is not supported", q.Op)
+	}
+	if strings.ContainsRune(q.Value, ';') {
+		return errors.Errorf("query value %q contains ';'", q.Value)
+	}
+	if q.invalid != "" {
+		return errors.Errorf("query value %q contains ','", q.invalid)
+	}
+	if q.Op == OperatorIn && q.Value == "" {
+		return errors.Errorf("query on %q has no values", q.Filter)
+	}
+	return nil
+}
+
+// operators are the operators supported by the Cloud Controller. All of them
+// can be applied to any filter.
+var operators = []Operator{
+	OperatorEqual,
+	OperatorIn,
+	OperatorGreater,
+	OperatorGreaterOrEqual,
+	OperatorLess,
+	OperatorLessOrEqual,
+}
+
+// userFilters are the filters supported by the endpoints listing users.
+var userFilters = []Filter{
+	FilterSpaceGUID,
+	FilterOrganizationGUID,
+	FilterManagedOrganizationGUID,
+	FilterBillingManagedOrganizationGUID,
+	FilterAuditedOrganizationGUID,
+	FilterManagedSpaceGUID,
+	FilterAuditedSpaceGUID,
+}
+
+// endpointFilters maps list endpoints to the filters they support. In
+// endpoints of sub-collections, e.g. /v2/apps/:guid/routes, the GUID of the
+// parent resource is denoted by :guid.
+var endpointFilters = map[string][]Filter{
+	"/v2/organizations": {
+		FilterName,
+		FilterStatus,
+		FilterSpaceGUID,
+		FilterUserGUID,
+		FilterManagerGUID,
+		FilterBillingManagerGUID,
+		FilterAuditorGUID,
+	},
+	"/v2/spaces": {
+		FilterName,
+		FilterOrganizationGUID,
+		FilterDeveloperGUID,
+		FilterAppGUID,
+		FilterIsolationSegmentGUID,
+	},
+	"/v2/apps": {
+		FilterName,
+		FilterSpaceGUID,
+		FilterOrganizationGUID,
+		FilterStackGUID,
+		FilterDiego,
+	},
+	"/v2/routes": {
+		FilterHost,
+		FilterDomainGUID,
+		FilterOrganizationGUID,
+		FilterPath,
+		FilterPort,
+	},
+	"/v2/apps/:guid/routes": {
+		FilterHost,
+		FilterDomainGUID,
+		FilterPath,
+		FilterPort,
+	},
+	"/v2/shared_domains": {
+		FilterName,
+	},
+	"/v2/private_domains": {
+		FilterName,
+	},
+	"/v2/route_mappings": {
+		FilterAppGUID,
+		FilterRouteGUID,
+	},
+	"/v2/services": {
+		FilterLabel,
+		FilterProvider,
+		FilterActive,
+		FilterServiceBrokerGUID,
+		FilterUniqueID,
+	},
+	"/v2/service_plans": {
+		FilterServiceGUID,
+		FilterServiceInstanceGUID,
+		FilterServiceBrokerGUID,
+		FilterActive,
+		FilterUniqueID,
+	},
+	"/v2/service_instances": {
+		FilterName,
+		FilterSpaceGUID,
+		FilterOrganizationGUID,
+		FilterServicePlanGUID,
+		FilterServiceBindingGUID,
+		FilterServiceKeyGUID,
+		FilterGatewayName,
+	},
+	"/v2/user_provided_service_instances": {
+		FilterName,
+		FilterSpaceGUID,
+		FilterOrganizationGUID,
+	},
+	"/v2/service_bindings": {
+		FilterName,
+		FilterAppGUID,
+		FilterServiceInstanceGUID,
+	},
+	"/v2/service_keys": {
+		FilterName,
+		FilterServiceInstanceGUID,
+	},
+	"/v2/service_brokers": {
+		FilterName,
+		FilterSpaceGUID,
+	},
+	"/v2/service_plan_visibilities": {
+		FilterOrganizationGUID,
+		FilterServicePlanGUID,
+	},
+	"/v2/users":                                userFilters,
+	"/v2/organizations/:guid/managers":         userFilters,
+	"/v2/organizations/:guid/billing_managers": userFilters,
+	"/v2/organizations/:guid/auditors":         userFilters,
+	"/v2/organizations/:guid/users":            userFilters,
+	"/v2/spaces/:guid/managers":                userFilters,
+	"/v2/spaces/:guid/developers":              userFilters,
+	"/v2/spaces/:guid/auditors":                userFilters,
+	"/v2/quota_definitions": {
+		FilterName,
+	},
+	"/v2/space_quota_definitions": {
+		FilterOrganizationGUID,
+	},
+	"/v2/events": {
+		FilterTimestamp,
+		FilterType,
+		FilterActee,
+		FilterActor,
+		FilterSpaceGUID,
+		FilterOrganizationGUID,
+	},
+}
+
+// validateQueries checks whether the list endpoint at path supports the
+// filters of queries. Queries for endpoints not present in endpointFilters
+// are not checked.
+func validateQueries(path string, queries []Query) error {
+	path, _, _ = strings.Cut(path, "?")
+	filters, ok := endpointFiltersFor(path)
+	if !ok {
+		return nil
+	}
+	for _, q := range queries {
+		if !containsFilter(filters, q.Filter) {
+			if len(filters) == 0 {
+				return errors.Errorf("filter %q is not supported by %s, which supports no filters", q.Filter, path)
+			}
+			return errors.Errorf("filter %q is not supported by %s, supported filters are: %s",
+				q.Filter, path, strings.Join(filterNames(filters), ", "))
+		}
+	}
+	return nil
+}
+
+// endpointFiltersFor returns the filters supported by the list endpoint at
+// path.
+func endpointFiltersFor(path string) ([]Filter, bool) {
+	if filters, ok := endpointFilters[path]; ok {
+		return filters, true
+	}
+	segments := strings.Split(path, "/")
+	for pattern, filters := range endpointFilters {
+		if matchesEndpoint(strings.Split(pattern, "/"), segments) {
+			return filters, true
+		}
+	}
+	return nil, false
+}
+
+// matchesEndpoint reports whether the path segments match the segments of an
+// endpointFilters key.
+func matchesEndpoint(pattern, segments []string) bool {
+	if len(pattern) != len(segments) {
+		return false
+	}
+	for i, p := range pattern {
+		if p == ":guid" {
+			if segments[i] == "" {
+				return false
+			}
+			continue
+		}
+		if p != segments[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func filterNames(filters []Filter) []string {
+	names := make([]string, len(filters))
+	for i, f := range filters {
+		names[i] = string(f)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func containsFilter(filters []Filter, filter Filter) bool {
+	for _, f := range filters {
+		if f == filter {
+			return true
+		}
+	}
+	return false
+}
+
+func containsOperator(ops []Operator, op Operator) bool {
+	for _, o := range ops {
+		if o == op {
+			return true
+		}
+	}
+	return false
+}
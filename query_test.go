@@ -0,0 +1,149 @@
+package ccv2_test
+
+import (
+	"context"
+	"net/http"
+
+	. "github.com/Bo0mer/ccv2"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("Query", func() {
+	DescribeTable("String",
+		func(q Query, expected string) {
+			Ω(q.String()).Should(Equal(expected))
+		},
+		Entry("equal", Eq(FilterName, "NASA"), "name:NASA"),
+		Entry("greater", Gt(FilterTimestamp, "2016-06-08T16:41:23Z"), "timestamp>2016-06-08T16:41:23Z"),
+		Entry("greater or equal", Ge(FilterTimestamp, "2016-06-08T16:41:23Z"), "timestamp>=2016-06-08T16:41:23Z"),
+		Entry("less", Lt(FilterTimestamp, "2016-06-08T16:41:23Z"), "timestamp<2016-06-08T16:41:23Z"),
+		Entry("less or equal", Le(FilterTimestamp, "2016-06-08T16:41:23Z"), "timestamp<=2016-06-08T16:41:23Z"),
+		Entry("in", In(FilterType, "audit.app.crash", "audit.app.update"), "type IN audit.app.crash,audit.app.update"),
+		Entry("in with plain value", Query{Filter: FilterType, Op: OperatorIn, Value: "a,b"}, "type IN a,b"),
+	)
+
+	It("should be comparable", func() {
+		seen := map[Query]bool{In(FilterType, "a", "b"): true}
+		Ω(seen[In(FilterType, "a", "b")]).Should(BeTrue())
+		Ω(In(FilterType, "a", "b")).Should(Equal(Query{Filter: FilterType, Op: OperatorIn, Value: "a,b"}))
+	})
+
+	Describe("sending queries", func() {
+		var client *Client
+		var server *ghttp.Server
+
+		var queries []Query
+		var err error
+
+		BeforeEach(func() {
+			client, server = setupTestClientAndServer()
+		})
+
+		AfterEach(func() {
+			server.Close()
+		})
+
+		JustBeforeEach(func() {
+			_, err = client.Events(context.Background(), queries...)
+		})
+
+		Context("when the queries are valid", func() {
+			BeforeEach(func() {
+				queries = []Query{
+					In(FilterType, "audit.app.crash", "audit.app.update"),
+					Ge(FilterTimestamp, "2016-06-08T16:41:23Z"),
+				}
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/v2/events",
+							"q=type+IN+audit.app.crash%2Caudit.app.update&q=timestamp%3E%3D2016-06-08T16%3A41%3A23Z"),
+						ghttp.RespondWith(http.StatusOK, `{"resources": []}`),
+					),
+				)
+			})
+
+			It("should have encoded the queries", func() {
+				Ω(err).ShouldNot(HaveOccurred())
+			})
+		})
+
+		Context("when a value contains a semicolon", func() {
+			BeforeEach(func() {
+				queries = []Query{Eq(FilterActee, "a;b")}
+			})
+
+			It("should have returned an error without sending a request", func() {
+				Ω(err).Should(MatchError(ContainSubstring("contains ';'")))
+				Ω(server.ReceivedRequests()).Should(BeEmpty())
+			})
+		})
+
+		Context("when an IN value contains a comma", func() {
+			BeforeEach(func() {
+				queries = []Query{In(FilterActee, "a", "b,c")}
+			})
+
+			It("should have returned an error without sending a request", func() {
+				Ω(err).Should(MatchError(ContainSubstring("contains ','")))
+				Ω(server.ReceivedRequests()).Should(BeEmpty())
+			})
+		})
+
+		Context("when an IN query has no values", func() {
+			BeforeEach(func() {
+				queries = []Query{In(FilterActee)}
+			})
+
+			It("should have returned an error without sending a request", func() {
+				Ω(err).Should(MatchError(ContainSubstring("has no values")))
+				Ω(server.ReceivedRequests()).Should(BeEmpty())
+			})
+		})
+
+		Context("when the operator is unknown", func() {
+			BeforeEach(func() {
+				queries = []Query{{Filter: FilterType, Op: "~", Value: "app.crash"}}
+			})
+
+			It("should have returned an error without sending a request", func() {
+				Ω(err).Should(MatchError(ContainSubstring(`operator "~" is not supported`)))
+				Ω(server.ReceivedRequests()).Should(BeEmpty())
+			})
+		})
+	})
+
+	Describe("sending unsupported queries", func() {
+		var client *Client
+		var server *ghttp.Server
+
+		BeforeEach(func() {
+			client, server = setupTestClientAndServer()
+		})
+
+		AfterEach(func() {
+			server.Close()
+		})
+
+		It("should have accepted any operator on a supported filter", func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/v2/organizations", "q=name%3Em"),
+					ghttp.RespondWith(http.StatusOK, `{"resources": []}`),
+				),
+			)
+			_, err := client.Organizations(context.Background(), Gt(FilterName, "m"))
+			Ω(err).ShouldNot(HaveOccurred())
+		})
+
+		It("should have returned an error naming the endpoint and the supported filters", func() {
+			_, err := client.Spaces(context.Background(), Eq(FilterTimestamp, "2016-06-08T16:41:23Z"))
+			Ω(err).Should(MatchError(ContainSubstring(`filter "timestamp" is not supported by /v2/spaces, ` +
+				"supported filters are: app_guid, developer_guid, isolation_segment_guid, name, organization_guid")))
+			Ω(server.ReceivedRequests()).Should(BeEmpty())
+		})
+	})
+})
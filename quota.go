@@ -0,0 +1,90 @@
+package ccv2
+
+import (
+	"context"
+	"iter"
+)
+
+// QuotaUnlimited is the value of quota limits that are not enforced.
+const QuotaUnlimited = -1
+
+// QuotaDefinition represents a quota that limits the resources of an
+// organization.
+type QuotaDefinition = Resource[QuotaDefinitionEntity]
+
+// QuotaDefinitionEntity holds the fields of an organization quota
+// definition. Memory limits are in megabytes.
+type QuotaDefinitionEntity struct {
+	Name                    string `json:"name"`
+	NonBasicServicesAllowed bool   `json:"non_basic_services_allowed"`
+	TotalServices           int    `json:"total_services"`
+	TotalServiceKeys        int    `json:"total_service_keys"`
+	TotalRoutes             int    `json:"total_routes"`
+	TotalReservedRoutePorts int    `json:"total_reserved_route_ports"`
+	TotalPrivateDomains     int    `json:"total_private_domains"`
+	MemoryLimit             int    `json:"memory_limit"`
+	InstanceMemoryLimit     int    `json:"instance_memory_limit"`
+	AppInstanceLimit        int    `json:"app_instance_limit"`
+	AppTaskLimit            int    `json:"app_task_limit"`
+	TrialDBAllowed          bool   `json:"trial_db_allowed"`
+}
+
+// QuotaDefinitions list all organization quota definitions that conform to
+// the provided queries.
+func (c *Client) QuotaDefinitions(ctx context.Context, queries ...Query) ([]QuotaDefinition, error) {
+	return ListResources[QuotaDefinitionEntity](ctx, c, "/v2/quota_definitions", queries...)
+}
+
+// EachQuotaDefinition returns an iterator over all organization quota
+// definitions that conform to the provided queries. Unlike QuotaDefinitions,
+// it holds at most one page in memory and stops requesting pages as soon as
+// the loop is exited.
+func (c *Client) EachQuotaDefinition(ctx context.Context, queries ...Query) iter.Seq2[QuotaDefinition, error] {
+	return EachResource[QuotaDefinitionEntity](ctx, c, "/v2/quota_definitions", queries...)
+}
+
+// QuotaDefinition returns the organization quota definition with the given
+// GUID, e.g. the one referenced by Organization.Entity.QuotaDefinitionGUID.
+func (c *Client) QuotaDefinition(ctx context.Context, guid string) (QuotaDefinition, error) {
+	return GetResource[QuotaDefinitionEntity](ctx, c, "/v2/quota_definitions", guid)
+}
+
+// SpaceQuotaDefinition represents a quota that limits the resources of a
+// space.
+type SpaceQuotaDefinition = Resource[SpaceQuotaDefinitionEntity]
+
+// SpaceQuotaDefinitionEntity holds the fields of a space quota definition.
+// Memory limits are in megabytes.
+type SpaceQuotaDefinitionEntity struct {
+	Name                    string `json:"name"`
+	OrganizationGUID        string `json:"organization_guid"`
+	NonBasicServicesAllowed bool   `json:"non_basic_services_allowed"`
+	TotalServices           int    `json:"total_services"`
+	TotalServiceKeys        int    `json:"total_service_keys"`
+	TotalRoutes             int    `json:"total_routes"`
+	TotalReservedRoutePorts int    `json:"total_reserved_route_ports"`
+	MemoryLimit             int    `json:"memory_limit"`
+	InstanceMemoryLimit     int    `json:"instance_memory_limit"`
+	AppInstanceLimit        int    `json:"app_instance_limit"`
+	AppTaskLimit            int    `json:"app_task_limit"`
+}
+
+// SpaceQuotaDefinitions list all space quota definitions that conform to the
+// provided queries.
+func (c *Client) SpaceQuotaDefinitions(ctx context.Context, queries ...Query) ([]SpaceQuotaDefinition, error) {
+	return ListResources[SpaceQuotaDefinitionEntity](ctx, c, "/v2/space_quota_definitions", queries...)
+}
+
+// EachSpaceQuotaDefinition returns an iterator over all space quota
+// definitions that conform to the provided queries. Unlike
+// SpaceQuotaDefinitions, it holds at most one page in memory and stops
+// requesting pages as soon as the loop is exited.
+func (c *Client) EachSpaceQuotaDefinition(ctx context.Context, queries ...Query) iter.Seq2[SpaceQuotaDefinition, error] {
+	return EachResource[SpaceQuotaDefinitionEntity](ctx, c, "/v2/space_quota_definitions", queries...)
+}
+
+// SpaceQuotaDefinition returns the space quota definition with the given
+// GUID, e.g. the one referenced by Space.Entity.SpaceQuotaDefinitionGUID.
+func (c *Client) SpaceQuotaDefinition(ctx context.Context, guid string) (SpaceQuotaDefinition, error) {
+	return GetResource[SpaceQuotaDefinitionEntity](ctx, c, "/v2/space_quota_definitions", guid)
+}
@@ -0,0 +1,180 @@
+package ccv2_test
+
+import (
+	"context"
+	"net/http"
+
+	. "github.com/Bo0mer/ccv2"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("Quotas", func() {
+	var client *Client
+	var server *ghttp.Server
+
+	var err error
+
+	BeforeEach(func() {
+		client, server = setupTestClientAndServer()
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	Describe("QuotaDefinitions", func() {
+		var quotas []QuotaDefinition
+
+		JustBeforeEach(func() {
+			quotas, err = client.QuotaDefinitions(context.Background(), Eq(FilterName, "default"))
+		})
+
+		Context("when the server returns a valid response", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/v2/quota_definitions", "q=name%3Adefault"),
+						ghttp.RespondWith(http.StatusOK, `
+{
+    "next_url": null,
+    "resources": [
+        {
+            "metadata": {
+                "guid": "dcb680a9-b190-4838-a3d2-b84aa17517a6",
+                "url": "/v2/quota_definitions/dcb680a9-b190-4838-a3d2-b84aa17517a6",
+                "created_at": "2016-06-08T16:41:39Z",
+                "updated_at": null
+            },
+            "entity": {
+                "name": "default",
+                "non_basic_services_allowed": true,
+                "total_services": 100,
+                "total_routes": 1000,
+                "total_private_domains": -1,
+                "memory_limit": 10240,
+                "trial_db_allowed": false,
+                "instance_memory_limit": -1,
+                "app_instance_limit": -1,
+                "app_task_limit": -1,
+                "total_service_keys": -1,
+                "total_reserved_route_ports": 0
+            }
+        }
+    ]
+}`),
+					),
+				)
+			})
+
+			It("should have returned the quota definitions", func() {
+				Ω(err).ShouldNot(HaveOccurred())
+				Ω(quotas).Should(HaveLen(1))
+				quota := quotas[0]
+				Ω(quota.GUID).Should(Equal("dcb680a9-b190-4838-a3d2-b84aa17517a6"))
+				Ω(quota.Entity).Should(Equal(QuotaDefinitionEntity{
+					Name:                    "default",
+					NonBasicServicesAllowed: true,
+					TotalServices:           100,
+					TotalServiceKeys:        QuotaUnlimited,
+					TotalRoutes:             1000,
+					TotalReservedRoutePorts: 0,
+					TotalPrivateDomains:     QuotaUnlimited,
+					MemoryLimit:             10240,
+					InstanceMemoryLimit:     QuotaUnlimited,
+					AppInstanceLimit:        QuotaUnlimited,
+					AppTaskLimit:            QuotaUnlimited,
+				}))
+			})
+		})
+
+		Context("when the server returns a non-2XX response", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(notFoundHandler())
+			})
+
+			It("should have returned a UnexpectedResponseError", func() {
+				Ω(err).Should(beNotFoundErr())
+			})
+		})
+	})
+
+	Describe("SpaceQuotaDefinitions", func() {
+		var quotas []SpaceQuotaDefinition
+
+		Context("when filtering by organization", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/v2/space_quota_definitions", "q=organization_guid%3Ad154425c-dccc-42e6-b6b4-27d46c3b42cb"),
+						ghttp.RespondWith(http.StatusOK, `{"next_url": null, "resources": [{"metadata": {"guid": "a9097bc8-c6cf-4a8f-bc47-623fa22e8019"}}]}`),
+					),
+				)
+			})
+
+			It("should have returned the space quota definitions of the organization", func() {
+				quotas, err = client.SpaceQuotaDefinitions(context.Background(), Eq(FilterOrganizationGUID, "d154425c-dccc-42e6-b6b4-27d46c3b42cb"))
+				Ω(err).ShouldNot(HaveOccurred())
+				Ω(quotas).Should(HaveLen(1))
+			})
+		})
+
+		Context("when filtering by an unsupported filter", func() {
+			It("should have returned an error without sending a request", func() {
+				quotas, err = client.SpaceQuotaDefinitions(context.Background(), Eq(FilterSpaceGUID, "1053174d-eb79-4f16-bf82-9f83a52d6e84"))
+				Ω(err).Should(MatchError(ContainSubstring(`filter "space_guid" is not supported by /v2/space_quota_definitions`)))
+				Ω(server.ReceivedRequests()).Should(BeEmpty())
+			})
+		})
+	})
+
+	Describe("SpaceQuotaDefinition", func() {
+		var quota SpaceQuotaDefinition
+
+		JustBeforeEach(func() {
+			quota, err = client.SpaceQuotaDefinition(context.Background(), "a9097bc8-c6cf-4a8f-bc47-623fa22e8019")
+		})
+
+		Context("when the server returns a valid response", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/v2/space_quota_definitions/a9097bc8-c6cf-4a8f-bc47-623fa22e8019"),
+						ghttp.RespondWith(http.StatusOK, `
+{
+    "metadata": {
+        "guid": "a9097bc8-c6cf-4a8f-bc47-623fa22e8019"
+    },
+    "entity": {
+        "name": "small",
+        "organization_guid": "d154425c-dccc-42e6-b6b4-27d46c3b42cb",
+        "non_basic_services_allowed": false,
+        "total_services": 10,
+        "total_routes": 20,
+        "memory_limit": 2048,
+        "instance_memory_limit": 512,
+        "app_instance_limit": 10,
+        "app_task_limit": 5,
+        "total_service_keys": 10,
+        "total_reserved_route_ports": 0
+    }
+}`),
+					),
+				)
+			})
+
+			It("should have returned the space quota definition", func() {
+				Ω(err).ShouldNot(HaveOccurred())
+				Ω(quota.Entity.Name).Should(Equal("small"))
+				Ω(quota.Entity.OrganizationGUID).Should(Equal("d154425c-dccc-42e6-b6b4-27d46c3b42cb"))
+				Ω(quota.Entity.MemoryLimit).Should(Equal(2048))
+				Ω(quota.Entity.InstanceMemoryLimit).Should(Equal(512))
+				Ω(quota.Entity.AppInstanceLimit).Should(Equal(10))
+				Ω(quota.Entity.TotalRoutes).Should(Equal(20))
+				Ω(quota.Entity.TotalServices).Should(Equal(10))
+			})
+		})
+	})
+})
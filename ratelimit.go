@@ -0,0 +1,242 @@
+package ccv2
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// RateLimitStatus represents the rate limit state reported by the Cloud
+// Controller via the X-RateLimit-* response headers.
+type RateLimitStatus struct {
+	// Limit is the total number of requests allowed in the current window.
+	Limit int
+	// Remaining is the number of requests left in the current window.
+	Remaining int
+	// Reset is the time at which the current window ends.
+	Reset time.Time
+}
+
+// parseRateLimitStatus extracts the rate limit status from the response
+// headers. It reports false if the Cloud Controller did not send any.
+func parseRateLimitStatus(h http.Header) (RateLimitStatus, bool) {
+	limit, lerr := strconv.Atoi(h.Get("X-RateLimit-Limit"))
+	remaining, rerr := strconv.Atoi(h.Get("X-RateLimit-Remaining"))
+	if lerr != nil || rerr != nil {
+		return RateLimitStatus{}, false
+	}
+	status := RateLimitStatus{
+		Limit:     limit,
+		Remaining: remaining,
+	}
+	if reset, err := strconv.ParseInt(h.Get("X-RateLimit-Reset"), 10, 64); err == nil {
+		status.Reset = time.Unix(reset, 0)
+	}
+	return status, true
+}
+
+// RateLimitError is returned by a RateLimiter instead of holding a request
+// back until the rate limit reported by the Cloud Controller resets, when
+// the reset is too far away. It matches ErrRateLimited.
+type RateLimitError struct {
+	// Status is the rate limit status that the request was refused for.
+	Status RateLimitStatus
+}
+
+// Error returns a description of the error.
+func (e *RateLimitError) Error() string {
+	return fmt.Sprintf("rate limit of %d requests exhausted until %s",
+		e.Status.Limit, e.Status.Reset.Format(time.RFC3339))
+}
+
+// Is reports whether target is ErrRateLimited.
+func (e *RateLimitError) Is(target error) bool {
+	return target == ErrRateLimited
+}
+
+// RateLimiter limits the rate at which requests are sent and the number of
+// requests in flight. A single RateLimiter may be shared by multiple clients
+// and is safe for concurrent use.
+//
+// The RateLimiter also keeps track of the rate limit status reported by the
+// Cloud Controller. When the Cloud Controller reports that no requests are
+// remaining, further requests are held back until the reported reset time,
+// or fail with a *RateLimitError if the reset is more than MaxResetWait away.
+type RateLimiter struct {
+	// MaxResetWait is the longest time requests are held back waiting for
+	// the reset of an exhausted rate limit. Zero means that such requests
+	// fail right away. NewRateLimiter sets it to one minute, in line with
+	// DefaultRetryPolicy.
+	MaxResetWait time.Duration
+
+	rate     float64
+	burst    int
+	inFlight chan struct{}
+
+	mu     sync.Mutex
+	tokens float64
+	last   time.Time
+	status RateLimitStatus
+	seen   bool
+}
+
+// NewRateLimiter creates a token bucket rate limiter that allows rate
+// requests per second with bursts of up to burst requests, and no more than
+// maxInFlight concurrent requests. Zero rate or maxInFlight mean that the
+// corresponding limit is not enforced.
+func NewRateLimiter(rate float64, burst, maxInFlight int) *RateLimiter {
+	if burst < 1 {
+		burst = 1
+	}
+	l := &RateLimiter{
+		MaxResetWait: time.Minute,
+		rate:         rate,
+		burst:        burst,
+		tokens:       float64(burst),
+	}
+	if maxInFlight > 0 {
+		l.inFlight = make(chan struct{}, maxInFlight)
+	}
+	return l
+}
+
+// Status returns the rate limit status of the latest window reported by the
+// Cloud Controller, with the fewest remaining requests reported for it. It
+// reports false if no status has been observed yet.
+func (l *RateLimiter) Status() (RateLimitStatus, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.status, l.seen
+}
+
+// acquire blocks until a request may be sent. The returned function must be
+// called once the request is complete.
+func (l *RateLimiter) acquire(ctx context.Context) (release func(), err error) {
+	d, err := l.reserve(time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if d > 0 {
+		timer := time.NewTimer(d)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			l.refund()
+			return nil, ctx.Err()
+		}
+	}
+	if l.inFlight == nil {
+		return func() {}, nil
+	}
+	select {
+	case l.inFlight <- struct{}{}:
+	case <-ctx.Done():
+		l.refund()
+		return nil, ctx.Err()
+	}
+	var once sync.Once
+	return func() {
+		once.Do(func() { <-l.inFlight })
+	}, nil
+}
+
+// reserve takes a token from the bucket and returns how long the caller must
+// wait before sending its request. It fails without taking a token if the
+// rate limit reported by the Cloud Controller is exhausted for longer than
+// l.MaxResetWait.
+func (l *RateLimiter) reserve(now time.Time) (time.Duration, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	var d time.Duration
+	if l.seen && l.status.Remaining <= 0 && now.Before(l.status.Reset) {
+		d = l.status.Reset.Sub(now)
+		if d > l.MaxResetWait {
+			return 0, &RateLimitError{Status: l.status}
+		}
+	}
+	if l.rate > 0 {
+		if !l.last.IsZero() {
+			elapsed := now.Sub(l.last).Seconds()
+			l.tokens = min(float64(l.burst), l.tokens+elapsed*l.rate)
+		}
+		l.last = now
+		l.tokens--
+		if l.tokens < 0 {
+			d = max(d, time.Duration(-l.tokens/l.rate*float64(time.Second)))
+		}
+	}
+	return d, nil
+}
+
+// refund returns the token taken by reserve for a request that was not sent.
+func (l *RateLimiter) refund() {
+	if l.rate <= 0 {
+		return
+	}
+	l.mu.Lock()
+	l.tokens = min(float64(l.burst), l.tokens+1)
+	l.mu.Unlock()
+}
+
+// observe records the rate limit status reported in resp, if any. As
+// responses to concurrent requests may arrive out of order, a status only
+// replaces the recorded one if it belongs to a later window or reports fewer
+// remaining requests in the same window.
+func (l *RateLimiter) observe(resp *http.Response) {
+	status, ok := parseRateLimitStatus(resp.Header)
+	if !ok {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.seen {
+		switch {
+		case status.Reset.Before(l.status.Reset):
+			return
+		case status.Reset.Equal(l.status.Reset) && status.Remaining >= l.status.Remaining:
+			return
+		}
+	}
+	l.status, l.seen = status, true
+}
+
+// releasingBody releases the rate limiter slot of a request once the
+// response body is closed.
+type releasingBody struct {
+	io.ReadCloser
+	release func()
+}
+
+func (b *releasingBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.release()
+	return err
+}
+
+// send sends a single request using c.HTTPClient, subject to c.Limiter.
+func (c *Client) send(req *http.Request) (*http.Response, error) {
+	l := c.Limiter
+	if l == nil {
+		return c.HTTPClient.Do(req)
+	}
+	release, err := l.acquire(req.Context())
+	if err != nil {
+		return nil, errors.Wrap(err, "waiting for rate limiter failed")
+	}
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		release()
+		return nil, err
+	}
+	l.observe(resp)
+	resp.Body = &releasingBody{ReadCloser: resp.Body, release: release}
+	return resp, nil
+}
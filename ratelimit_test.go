@@ -0,0 +1,241 @@
+package ccv2_test
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/pkg/errors"
+
+	. "github.com/Bo0mer/ccv2"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("RateLimiter", func() {
+	var client *Client
+	var server *ghttp.Server
+
+	BeforeEach(func() {
+		client, server = setupTestClientAndServer()
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	Context("when the server reports rate limit headers", func() {
+		var reset time.Time
+
+		BeforeEach(func() {
+			client.Limiter = NewRateLimiter(0, 0, 0)
+			reset = time.Unix(time.Now().Unix(), 0)
+			server.AppendHandlers(
+				ghttp.RespondWith(http.StatusOK, `{}`, http.Header{
+					"X-RateLimit-Limit":     []string{"100"},
+					"X-RateLimit-Remaining": []string{"42"},
+					"X-RateLimit-Reset":     []string{strconv.FormatInt(reset.Unix(), 10)},
+				}),
+			)
+		})
+
+		It("should have recorded the rate limit status", func() {
+			_, err := client.Info(context.Background())
+			Ω(err).ShouldNot(HaveOccurred())
+
+			status, ok := client.Limiter.Status()
+			Ω(ok).Should(BeTrue())
+			Ω(status.Limit).Should(Equal(100))
+			Ω(status.Remaining).Should(Equal(42))
+			Ω(status.Reset).Should(Equal(reset))
+		})
+	})
+
+	Context("when statuses of concurrent requests arrive out of order", func() {
+		var reset time.Time
+
+		rateLimited := func(remaining int, reset time.Time) http.HandlerFunc {
+			return ghttp.RespondWith(http.StatusOK, `{}`, http.Header{
+				"X-RateLimit-Limit":     []string{"100"},
+				"X-RateLimit-Remaining": []string{strconv.Itoa(remaining)},
+				"X-RateLimit-Reset":     []string{strconv.FormatInt(reset.Unix(), 10)},
+			})
+		}
+
+		BeforeEach(func() {
+			client.Limiter = NewRateLimiter(0, 0, 0)
+			reset = time.Unix(time.Now().Unix(), 0)
+			server.AppendHandlers(
+				rateLimited(3, reset),
+				rateLimited(7, reset),
+				rateLimited(9, reset.Add(-time.Hour)),
+			)
+		})
+
+		It("should have kept the status with the fewest remaining requests", func() {
+			for i := 0; i < 3; i++ {
+				_, err := client.Info(context.Background())
+				Ω(err).ShouldNot(HaveOccurred())
+			}
+
+			status, ok := client.Limiter.Status()
+			Ω(ok).Should(BeTrue())
+			Ω(status.Remaining).Should(Equal(3))
+			Ω(status.Reset).Should(Equal(reset))
+		})
+
+		It("should have replaced the status once a new window starts", func() {
+			server.AppendHandlers(rateLimited(99, reset.Add(time.Hour)))
+			for i := 0; i < 4; i++ {
+				_, err := client.Info(context.Background())
+				Ω(err).ShouldNot(HaveOccurred())
+			}
+
+			status, _ := client.Limiter.Status()
+			Ω(status.Remaining).Should(Equal(99))
+			Ω(status.Reset).Should(Equal(reset.Add(time.Hour)))
+		})
+	})
+
+	Context("when a successful response reports that no requests are remaining", func() {
+		var reset time.Time
+
+		BeforeEach(func() {
+			client.Limiter = NewRateLimiter(0, 0, 0)
+			reset = time.Unix(time.Now().Add(time.Hour).Unix(), 0)
+			server.AppendHandlers(
+				ghttp.RespondWith(http.StatusOK, `{}`, http.Header{
+					"X-RateLimit-Limit":     []string{"100"},
+					"X-RateLimit-Remaining": []string{"0"},
+					"X-RateLimit-Reset":     []string{strconv.FormatInt(reset.Unix(), 10)},
+				}),
+			)
+		})
+
+		It("should have failed further requests without sending them", func() {
+			_, err := client.Info(context.Background())
+			Ω(err).ShouldNot(HaveOccurred())
+
+			start := time.Now()
+			_, err = client.Info(context.Background())
+			Ω(time.Since(start)).Should(BeNumerically("<", time.Second))
+			Ω(errors.Is(err, ErrRateLimited)).Should(BeTrue())
+			var e *RateLimitError
+			Ω(errors.As(err, &e)).Should(BeTrue())
+			Ω(e.Status.Remaining).Should(Equal(0))
+			Ω(e.Status.Reset).Should(Equal(reset))
+			Ω(server.ReceivedRequests()).Should(HaveLen(1))
+		})
+
+		Context("and the reset is within MaxResetWait", func() {
+			BeforeEach(func() {
+				client.Limiter.MaxResetWait = 2 * time.Hour
+				server.AppendHandlers(ghttp.RespondWith(http.StatusOK, `{}`))
+			})
+
+			It("should have held further requests back", func() {
+				_, err := client.Info(context.Background())
+				Ω(err).ShouldNot(HaveOccurred())
+
+				ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+				defer cancel()
+				_, err = client.Info(ctx)
+				Ω(err).Should(MatchError(context.DeadlineExceeded))
+				Ω(server.ReceivedRequests()).Should(HaveLen(1))
+			})
+		})
+	})
+
+	Context("when a rate is specified", func() {
+		BeforeEach(func() {
+			client.Limiter = NewRateLimiter(50, 1, 0)
+			server.RouteToHandler("GET", "/v2/info", ghttp.RespondWith(http.StatusOK, `{}`))
+		})
+
+		It("should have spaced the requests", func() {
+			start := time.Now()
+			for i := 0; i < 3; i++ {
+				_, err := client.Info(context.Background())
+				Ω(err).ShouldNot(HaveOccurred())
+			}
+			Ω(time.Since(start)).Should(BeNumerically(">=", 35*time.Millisecond))
+		})
+
+		It("should have returned the token of a cancelled request", func() {
+			client.Limiter = NewRateLimiter(5, 1, 0)
+			_, err := client.Info(context.Background())
+			Ω(err).ShouldNot(HaveOccurred())
+
+			ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+			defer cancel()
+			_, err = client.Info(ctx)
+			Ω(err).Should(MatchError(context.DeadlineExceeded))
+
+			start := time.Now()
+			_, err = client.Info(context.Background())
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(time.Since(start)).Should(BeNumerically("<", 300*time.Millisecond))
+		})
+	})
+
+	Context("when the number of requests in flight is capped", func() {
+		var inFlight, maxInFlight int32
+
+		BeforeEach(func() {
+			inFlight, maxInFlight = 0, 0
+			client.Limiter = NewRateLimiter(0, 0, 2)
+			server.RouteToHandler("GET", "/v2/info", func(w http.ResponseWriter, r *http.Request) {
+				n := atomic.AddInt32(&inFlight, 1)
+				defer atomic.AddInt32(&inFlight, -1)
+				for {
+					m := atomic.LoadInt32(&maxInFlight)
+					if n <= m || atomic.CompareAndSwapInt32(&maxInFlight, m, n) {
+						break
+					}
+				}
+				time.Sleep(10 * time.Millisecond)
+				w.Write([]byte(`{}`))
+			})
+		})
+
+		It("should have not exceeded the cap", func() {
+			var wg sync.WaitGroup
+			for i := 0; i < 6; i++ {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					defer GinkgoRecover()
+					_, err := client.Info(context.Background())
+					Ω(err).ShouldNot(HaveOccurred())
+				}()
+			}
+			wg.Wait()
+			Ω(atomic.LoadInt32(&maxInFlight)).Should(BeNumerically("<=", 2))
+		})
+	})
+
+	Context("when the server rate limits a retried request", func() {
+		BeforeEach(func() {
+			client.Retry = &RetryPolicy{MaxAttempts: 2}
+			server.AppendHandlers(
+				ghttp.RespondWith(http.StatusTooManyRequests, `{}`, http.Header{
+					"X-RateLimit-Limit":     []string{"100"},
+					"X-RateLimit-Remaining": []string{"0"},
+					"X-RateLimit-Reset":     []string{strconv.FormatInt(time.Now().Unix(), 10)},
+				}),
+				ghttp.RespondWith(http.StatusOK, `{"name": "vcap"}`),
+			)
+		})
+
+		It("should have retried the request", func() {
+			info, err := client.Info(context.Background())
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(info.Name).Should(Equal("vcap"))
+		})
+	})
+})
@@ -0,0 +1,190 @@
+package ccv2
+
+import (
+	"context"
+	"encoding/json"
+	"iter"
+	"net/http"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Metadata represents metadata for a resource.
+type Metadata struct {
+	GUID string `json:"guid"`
+	// URL is the path of the resource, relative to the API URL.
+	URL       string    `json:"url"`
+	CreatedAt time.Time `json:"created_at"`
+	// UpdatedAt is the zero time for resources that have never been
+	// updated.
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// LastModified returns the time at which the resource was last updated or,
+// if it has never been updated, the time at which it was created.
+func (m Metadata) LastModified() time.Time {
+	if m.UpdatedAt.IsZero() {
+		return m.CreatedAt
+	}
+	return m.UpdatedAt
+}
+
+// Resource represents a Cloud Controller resource, which consists of
+// metadata and an entity holding the resource specific fields.
+//
+// Resources that are not modelled by this package can be used by defining
+// a struct for their entity, e.g.:
+//
+//	type StackEntity struct {
+//	  Name        string `json:"name"`
+//	  Description string `json:"description"`
+//	}
+//
+//	stacks, err := ccv2.ListResources[StackEntity](ctx, cf, "/v2/stacks")
+//
+// Entity fields that are not declared in E are not lost. They are kept in
+// Extra and the whole entity is kept in RawEntity, so that fields added by
+// newer Cloud Controller versions remain accessible.
+type Resource[E any] struct {
+	Metadata `json:"metadata"`
+
+	Entity E `json:"entity"`
+
+	// RawEntity is the entity as returned by the Cloud Controller.
+	RawEntity json.RawMessage `json:"-"`
+	// Extra holds the entity fields that are not declared in E, keyed by
+	// their JSON name.
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// UnmarshalJSON decodes the resource, keeping the raw entity and the entity
+// fields unknown to E.
+func (r *Resource[E]) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		Metadata Metadata        `json:"metadata"`
+		Entity   json.RawMessage `json:"entity"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	*r = Resource[E]{Metadata: raw.Metadata}
+	if len(raw.Entity) == 0 || string(raw.Entity) == "null" {
+		return nil
+	}
+	r.RawEntity = raw.Entity
+	if err := json.Unmarshal(raw.Entity, &r.Entity); err != nil {
+		return err
+	}
+
+	extra, err := unknownFields(raw.Entity, reflect.TypeOf(r.Entity))
+	if err != nil {
+		return err
+	}
+	r.Extra = extra
+	return nil
+}
+
+// unknownFields returns the fields of the JSON object data that are not
+// declared by struct type t, keyed by their JSON name. It returns nil if
+// there are no such fields or if t is not a struct.
+func unknownFields(data []byte, t reflect.Type) (map[string]json.RawMessage, error) {
+	known, ok := knownFields(t)
+	if !ok {
+		return nil, nil
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	var extra map[string]json.RawMessage
+	for name, value := range fields {
+		if known[strings.ToLower(name)] {
+			continue
+		}
+		if extra == nil {
+			extra = make(map[string]json.RawMessage)
+		}
+		extra[name] = value
+	}
+	return extra, nil
+}
+
+// knownFieldsCache maps struct types to their known fields.
+var knownFieldsCache sync.Map
+
+// knownFields returns the lower-cased JSON names of the fields of struct
+// type t, including the fields of embedded structs. It reports false if t
+// is not a struct.
+func knownFields(t reflect.Type) (map[string]bool, bool) {
+	if t == nil || t.Kind() != reflect.Struct {
+		return nil, false
+	}
+	if known, ok := knownFieldsCache.Load(t); ok {
+		return known.(map[string]bool), true
+	}
+	known := make(map[string]bool)
+	collectFields(t, known)
+	knownFieldsCache.Store(t, known)
+	return known, true
+}
+
+func collectFields(t reflect.Type, known map[string]bool) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, _, _ := strings.Cut(tag, ",")
+		if f.Anonymous && name == "" {
+			ft := f.Type
+			if ft.Kind() == reflect.Pointer {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				collectFields(ft, known)
+				continue
+			}
+		}
+		if !f.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		known[strings.ToLower(name)] = true
+	}
+}
+
+// ListResources lists all resources of the collection at path that conform
+// to the provided queries.
+func ListResources[E any](ctx context.Context, c *Client, path string, queries ...Query) ([]Resource[E], error) {
+	return list[Resource[E]](c, listRequest(ctx, path, queries))
+}
+
+// EachResource returns an iterator over all resources of the collection at
+// path that conform to the provided queries. It holds at most one page in
+// memory and stops requesting pages as soon as the loop is exited.
+func EachResource[E any](ctx context.Context, c *Client, path string, queries ...Query) iter.Seq2[Resource[E], error] {
+	return each[Resource[E]](c, listRequest(ctx, path, queries))
+}
+
+// GetResource returns the resource with the given GUID from the collection
+// at path.
+func GetResource[E any](ctx context.Context, c *Client, path, guid string) (Resource[E], error) {
+	var r Resource[E]
+	err := c.getResource(ctx, path, guid, &r)
+	return r, err
+}
+
+func listRequest(ctx context.Context, path string, queries []Query) requestOpts {
+	return requestOpts{
+		Context: ctx,
+		Method:  http.MethodGet,
+		Path:    path,
+		Queries: queries,
+	}
+}
@@ -0,0 +1,110 @@
+package ccv2_test
+
+import (
+	"context"
+	"net/http"
+
+	. "github.com/Bo0mer/ccv2"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+type stackEntity struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+var _ = Describe("Resource", func() {
+	var client *Client
+	var server *ghttp.Server
+
+	BeforeEach(func() {
+		client, server = setupTestClientAndServer()
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	Describe("ListResources", func() {
+		BeforeEach(func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/v2/stacks", "q=name%3Acflinuxfs2"),
+					ghttp.RespondWith(http.StatusOK, `
+{
+    "next_url": null,
+    "resources": [
+        {
+            "metadata": {"guid": "stack-guid"},
+            "entity": {"name": "cflinuxfs2", "description": "Cloud Foundry Linux-based filesystem"}
+        }
+    ]
+}`),
+				),
+			)
+		})
+
+		It("should have decoded the custom entities", func() {
+			stacks, err := ListResources[stackEntity](context.Background(), client, "/v2/stacks", Eq(FilterName, "cflinuxfs2"))
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(stacks).Should(HaveLen(1))
+			Ω(stacks[0].GUID).Should(Equal("stack-guid"))
+			Ω(stacks[0].Entity).Should(Equal(stackEntity{
+				Name:        "cflinuxfs2",
+				Description: "Cloud Foundry Linux-based filesystem",
+			}))
+		})
+	})
+
+	Describe("GetResource", func() {
+		BeforeEach(func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/v2/stacks/stack-guid"),
+					ghttp.RespondWith(http.StatusOK, `{"metadata": {"guid": "stack-guid"}, "entity": {"name": "cflinuxfs2"}}`),
+				),
+			)
+		})
+
+		It("should have decoded the custom entity", func() {
+			stack, err := GetResource[stackEntity](context.Background(), client, "/v2/stacks", "stack-guid")
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(stack.GUID).Should(Equal("stack-guid"))
+			Ω(stack.Entity.Name).Should(Equal("cflinuxfs2"))
+		})
+	})
+
+	Describe("Metadata", func() {
+		var org Organization
+
+		BeforeEach(func() {
+			server.AppendHandlers(
+				ghttp.RespondWith(http.StatusOK, `
+{
+    "metadata": {
+        "guid": "org-guid",
+        "url": "/v2/organizations/org-guid",
+        "created_at": "2016-06-08T16:41:33Z",
+        "updated_at": null
+    }
+}`),
+			)
+		})
+
+		JustBeforeEach(func() {
+			var err error
+			org, err = client.Organization(context.Background(), "org-guid")
+			Ω(err).ShouldNot(HaveOccurred())
+		})
+
+		It("should have tolerated the null updated_at", func() {
+			Ω(org.URL).Should(Equal("/v2/organizations/org-guid"))
+			Ω(org.CreatedAt).Should(Equal(parseTime("2016-06-08T16:41:33Z")))
+			Ω(org.UpdatedAt.IsZero()).Should(BeTrue())
+			Ω(org.LastModified()).Should(Equal(org.CreatedAt))
+		})
+	})
+})
@@ -0,0 +1,168 @@
+package ccv2
+
+import (
+	"io"
+	"math"
+	"math/rand"
+	"net"
+	"net/http"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// RetryPolicy specifies how requests that failed due to transient errors are
+// retried. A request is considered failed due to a transient error if the
+// HTTP client returned a network error, such as a connection reset, or if the
+// Cloud Controller responded with 429, 502, 503 or 504.
+//
+// Only GET requests are retried, as they are idempotent. When paginating,
+// each page is retried individually, so pages that were already fetched are
+// not requested again.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of times a request is sent,
+	// including the first attempt. Values below two disable retries.
+	MaxAttempts int
+	// MinBackoff is the base delay before the first retry. It is doubled for
+	// each subsequent retry. Up to half of the delay is randomized in order
+	// to avoid synchronized retries from multiple clients.
+	MinBackoff time.Duration
+	// MaxBackoff caps the delay between two attempts. Zero means no cap.
+	// Delays requested by the Cloud Controller via the Retry-After or
+	// X-RateLimit-Reset headers are governed by MaxRetryAfter instead, which
+	// falls back to MaxBackoff when unset.
+	MaxBackoff time.Duration
+	// MaxRetryAfter is the longest delay requested via the Retry-After or
+	// X-RateLimit-Reset headers that is waited for. Responses requesting a
+	// longer delay are returned without being retried. Zero means that
+	// MaxBackoff is used instead.
+	MaxRetryAfter time.Duration
+}
+
+// DefaultRetryPolicy is a reasonable retry policy for most clients.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts: 4,
+	MinBackoff:  500 * time.Millisecond,
+	MaxBackoff:  10 * time.Second,
+	// Rate limits of the Cloud Controller reset at most every hour, so
+	// waiting for a reset is not worthwhile in general.
+	MaxRetryAfter: time.Minute,
+}
+
+// backoff returns the delay before the given retry, starting from one.
+func (p *RetryPolicy) backoff(retry int) time.Duration {
+	d := p.MinBackoff
+	for i := 1; i < retry && d < math.MaxInt64/2; i++ {
+		if p.MaxBackoff > 0 && d >= p.MaxBackoff {
+			break
+		}
+		d *= 2
+	}
+	if p.MaxBackoff > 0 && d > p.MaxBackoff {
+		d = p.MaxBackoff
+	}
+	if d <= 0 {
+		return 0
+	}
+	half := d / 2
+	return half + time.Duration(rand.Int63n(int64(d-half)+1))
+}
+
+// maxRetryAfter returns the longest delay requested by the Cloud Controller
+// that is waited for, or zero if there is no limit.
+func (p *RetryPolicy) maxRetryAfter() time.Duration {
+	if p.MaxRetryAfter > 0 {
+		return p.MaxRetryAfter
+	}
+	return p.MaxBackoff
+}
+
+// do sends the request, retrying it according to c.Retry.
+func (c *Client) do(req *http.Request) (*http.Response, error) {
+	p := c.Retry
+	if p == nil || p.MaxAttempts < 2 || req.Method != http.MethodGet {
+		return c.send(req)
+	}
+
+	ctx := req.Context()
+	for attempt := 1; ; attempt++ {
+		resp, err := c.send(req.Clone(ctx))
+		if attempt >= p.MaxAttempts || ctx.Err() != nil || !isTransient(resp, err) {
+			return resp, err
+		}
+
+		delay := p.backoff(attempt)
+		if resp != nil {
+			if d, ok := retryAfter(resp); ok {
+				if limit := p.maxRetryAfter(); limit > 0 && d > limit {
+					return resp, nil
+				}
+				delay = d
+			}
+			io.Copy(io.Discard, io.LimitReader(resp.Body, 4096))
+			resp.Body.Close()
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, errors.Wrap(ctx.Err(), "waiting to retry request failed")
+		}
+	}
+}
+
+// isTransient reports whether the outcome of a request indicates a failure
+// that may not occur if the request is retried.
+func isTransient(resp *http.Response, err error) bool {
+	if err != nil {
+		var netErr net.Error
+		switch {
+		case errors.Is(err, syscall.ECONNRESET),
+			errors.Is(err, syscall.ECONNREFUSED),
+			errors.Is(err, io.EOF),
+			errors.Is(err, io.ErrUnexpectedEOF):
+			return true
+		case errors.As(err, &netErr):
+			return netErr.Timeout()
+		}
+		return false
+	}
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests,
+		http.StatusBadGateway,
+		http.StatusServiceUnavailable,
+		http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// retryAfter returns the delay requested by the Retry-After header of resp.
+// For rate limited responses without such header, the delay is derived from
+// the X-RateLimit-Reset header.
+func retryAfter(resp *http.Response) (time.Duration, bool) {
+	v := resp.Header.Get("Retry-After")
+	if v == "" {
+		if resp.StatusCode == http.StatusTooManyRequests {
+			if status, ok := parseRateLimitStatus(resp.Header); ok && !status.Reset.IsZero() {
+				return max(time.Until(status.Reset), 0), true
+			}
+		}
+		return 0, false
+	}
+	if secs, err := strconv.Atoi(v); err == nil && secs >= 0 {
+		return time.Duration(secs) * time.Second, true
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		d := time.Until(t)
+		if d < 0 {
+			d = 0
+		}
+		return d, true
+	}
+	return 0, false
+}
@@ -0,0 +1,137 @@
+package ccv2_test
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	. "github.com/Bo0mer/ccv2"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("RetryPolicy", func() {
+	var client *Client
+	var server *ghttp.Server
+
+	var err error
+
+	BeforeEach(func() {
+		client, server = setupTestClientAndServer()
+		client.Retry = &RetryPolicy{
+			MaxAttempts: 3,
+			MinBackoff:  time.Millisecond,
+			MaxBackoff:  5 * time.Millisecond,
+		}
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	Describe("Info", func() {
+		var info Info
+
+		JustBeforeEach(func() {
+			info, err = client.Info(context.Background())
+		})
+
+		Context("when the server recovers before the attempts are exhausted", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.RespondWith(http.StatusBadGateway, "<html>Bad Gateway</html>"),
+					ghttp.RespondWith(http.StatusServiceUnavailable, "", http.Header{
+						"Retry-After": []string{"0"},
+					}),
+					ghttp.RespondWith(http.StatusOK, `{"name": "vcap"}`),
+				)
+			})
+
+			It("should have retried the request", func() {
+				Ω(err).ShouldNot(HaveOccurred())
+				Ω(info.Name).Should(Equal("vcap"))
+				Ω(server.ReceivedRequests()).Should(HaveLen(3))
+			})
+		})
+
+		Context("when the server keeps failing", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.RespondWith(http.StatusGatewayTimeout, `{"description": "timeout"}`),
+					ghttp.RespondWith(http.StatusGatewayTimeout, `{"description": "timeout"}`),
+					ghttp.RespondWith(http.StatusGatewayTimeout, `{"description": "timeout"}`),
+				)
+			})
+
+			It("should have returned the last error", func() {
+				Ω(server.ReceivedRequests()).Should(HaveLen(3))
+				Ω(err).Should(BeAssignableToTypeOf(&UnexpectedResponseError{}))
+				Ω(err.(*UnexpectedResponseError).StatusCode).Should(Equal(http.StatusGatewayTimeout))
+			})
+		})
+
+		Context("when the server requests a delay longer than the limit", func() {
+			BeforeEach(func() {
+				client.Retry.MaxRetryAfter = time.Second
+				server.AppendHandlers(
+					ghttp.RespondWith(http.StatusServiceUnavailable, "", http.Header{
+						"Retry-After": []string{"86400"},
+					}),
+				)
+			})
+
+			It("should have returned the response without retrying", func() {
+				Ω(server.ReceivedRequests()).Should(HaveLen(1))
+				Ω(err).Should(MatchError(ErrServerError))
+			})
+		})
+
+		Context("when the server returns a non-transient error", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(notFoundHandler())
+			})
+
+			It("should have not retried the request", func() {
+				Ω(server.ReceivedRequests()).Should(HaveLen(1))
+				Ω(err).Should(beNotFoundErr())
+			})
+		})
+	})
+
+	Describe("Spaces", func() {
+		var spaces []Space
+
+		JustBeforeEach(func() {
+			spaces, err = client.Spaces(context.Background())
+		})
+
+		Context("when a page fails while paginating", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/v2/spaces"),
+						ghttp.RespondWith(http.StatusOK, `{"next_url": "/v2/spaces?page=2", "resources": [{"metadata": {"guid": "space-1"}}]}`),
+					),
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/v2/spaces", "page=2"),
+						ghttp.RespondWith(http.StatusServiceUnavailable, ""),
+					),
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/v2/spaces", "page=2"),
+						ghttp.RespondWith(http.StatusOK, `{"next_url": null, "resources": [{"metadata": {"guid": "space-2"}}]}`),
+					),
+				)
+			})
+
+			It("should have retried only the failed page", func() {
+				Ω(err).ShouldNot(HaveOccurred())
+				Ω(server.ReceivedRequests()).Should(HaveLen(3))
+				Ω(spaces).Should(HaveLen(2))
+				Ω(spaces[0].GUID).Should(Equal("space-1"))
+				Ω(spaces[1].GUID).Should(Equal("space-2"))
+			})
+		})
+	})
+})
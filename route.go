@@ -0,0 +1,177 @@
+package ccv2
+
+import (
+	"context"
+	"iter"
+	"net/url"
+	"strconv"
+)
+
+// Route represents a route through which applications are reachable.
+type Route = Resource[RouteEntity]
+
+// RouteEntity holds the fields of a route.
+type RouteEntity struct {
+	Host       string `json:"host"`
+	Path       string `json:"path"`
+	DomainGUID string `json:"domain_guid"`
+	SpaceGUID  string `json:"space_guid"`
+	// Port is zero for HTTP routes.
+	Port                int    `json:"port"`
+	ServiceInstanceGUID string `json:"service_instance_guid"`
+}
+
+// Routes list all routes that conform to the provided queries.
+func (c *Client) Routes(ctx context.Context, queries ...Query) ([]Route, error) {
+	return ListResources[RouteEntity](ctx, c, "/v2/routes", queries...)
+}
+
+// EachRoute returns an iterator over all routes that conform to the provided
+// queries. Unlike Routes, it holds at most one page in memory and stops
+// requesting pages as soon as the loop is exited.
+func (c *Client) EachRoute(ctx context.Context, queries ...Query) iter.Seq2[Route, error] {
+	return EachResource[RouteEntity](ctx, c, "/v2/routes", queries...)
+}
+
+// Route returns the route with the given GUID.
+func (c *Client) Route(ctx context.Context, guid string) (Route, error) {
+	return GetResource[RouteEntity](ctx, c, "/v2/routes", guid)
+}
+
+// ApplicationRoutes list all routes mapped to the given application.
+func (c *Client) ApplicationRoutes(ctx context.Context, app Application, queries ...Query) ([]Route, error) {
+	return ListResources[RouteEntity](ctx, c, "/v2/apps/"+url.PathEscape(app.GUID)+"/routes", queries...)
+}
+
+// SharedDomain represents a domain that is available to all organizations.
+type SharedDomain = Resource[SharedDomainEntity]
+
+// SharedDomainEntity holds the fields of a shared domain.
+type SharedDomainEntity struct {
+	Name string `json:"name"`
+	// RouterGroupGUID and RouterGroupType are set only for TCP domains.
+	RouterGroupGUID string `json:"router_group_guid"`
+	RouterGroupType string `json:"router_group_type"`
+	Internal        bool   `json:"internal"`
+}
+
+// SharedDomains list all shared domains that conform to the provided queries.
+func (c *Client) SharedDomains(ctx context.Context, queries ...Query) ([]SharedDomain, error) {
+	return ListResources[SharedDomainEntity](ctx, c, "/v2/shared_domains", queries...)
+}
+
+// EachSharedDomain returns an iterator over all shared domains that conform
+// to the provided queries. Unlike SharedDomains, it holds at most one page in
+// memory and stops requesting pages as soon as the loop is exited.
+func (c *Client) EachSharedDomain(ctx context.Context, queries ...Query) iter.Seq2[SharedDomain, error] {
+	return EachResource[SharedDomainEntity](ctx, c, "/v2/shared_domains", queries...)
+}
+
+// SharedDomain returns the shared domain with the given GUID.
+func (c *Client) SharedDomain(ctx context.Context, guid string) (SharedDomain, error) {
+	return GetResource[SharedDomainEntity](ctx, c, "/v2/shared_domains", guid)
+}
+
+// PrivateDomain represents a domain owned by an organization.
+type PrivateDomain = Resource[PrivateDomainEntity]
+
+// PrivateDomainEntity holds the fields of a private domain.
+type PrivateDomainEntity struct {
+	Name                   string `json:"name"`
+	OwningOrganizationGUID string `json:"owning_organization_guid"`
+}
+
+// PrivateDomains list all private domains that conform to the provided
+// queries.
+func (c *Client) PrivateDomains(ctx context.Context, queries ...Query) ([]PrivateDomain, error) {
+	return ListResources[PrivateDomainEntity](ctx, c, "/v2/private_domains", queries...)
+}
+
+// EachPrivateDomain returns an iterator over all private domains that conform
+// to the provided queries. Unlike PrivateDomains, it holds at most one page in
+// memory and stops requesting pages as soon as the loop is exited.
+func (c *Client) EachPrivateDomain(ctx context.Context, queries ...Query) iter.Seq2[PrivateDomain, error] {
+	return EachResource[PrivateDomainEntity](ctx, c, "/v2/private_domains", queries...)
+}
+
+// PrivateDomain returns the private domain with the given GUID.
+func (c *Client) PrivateDomain(ctx context.Context, guid string) (PrivateDomain, error) {
+	return GetResource[PrivateDomainEntity](ctx, c, "/v2/private_domains", guid)
+}
+
+// RouteMapping represents the mapping of a route to an application.
+type RouteMapping = Resource[RouteMappingEntity]
+
+// RouteMappingEntity holds the fields of a route mapping.
+type RouteMappingEntity struct {
+	AppGUID   string `json:"app_guid"`
+	RouteGUID string `json:"route_guid"`
+	// AppPort is the application port to which the route sends traffic.
+	AppPort int `json:"app_port"`
+}
+
+// RouteMappings list all route mappings that conform to the provided queries.
+func (c *Client) RouteMappings(ctx context.Context, queries ...Query) ([]RouteMapping, error) {
+	return ListResources[RouteMappingEntity](ctx, c, "/v2/route_mappings", queries...)
+}
+
+// EachRouteMapping returns an iterator over all route mappings that conform
+// to the provided queries. Unlike RouteMappings, it holds at most one page in
+// memory and stops requesting pages as soon as the loop is exited.
+func (c *Client) EachRouteMapping(ctx context.Context, queries ...Query) iter.Seq2[RouteMapping, error] {
+	return EachResource[RouteMappingEntity](ctx, c, "/v2/route_mappings", queries...)
+}
+
+// RouteMapping returns the route mapping with the given GUID.
+func (c *Client) RouteMapping(ctx context.Context, guid string) (RouteMapping, error) {
+	return GetResource[RouteMappingEntity](ctx, c, "/v2/route_mappings", guid)
+}
+
+// RouteSummary represents a route as included in an application summary.
+type RouteSummary struct {
+	GUID   string        `json:"guid"`
+	Host   string        `json:"host"`
+	Path   string        `json:"path"`
+	Port   int           `json:"port"`
+	Domain DomainSummary `json:"domain"`
+}
+
+// DomainSummary represents the domain of a route included in an application
+// summary.
+type DomainSummary struct {
+	GUID string `json:"guid"`
+	Name string `json:"name"`
+}
+
+// URL returns the URL of the route, without a scheme.
+func (r RouteSummary) URL() string {
+	return RouteURL(r.Host, r.Domain.Name, r.Path, r.Port)
+}
+
+// RouteURL renders the URL of a route, without a scheme, from its parts,
+// e.g. host.domain/path for HTTP routes and domain:port for TCP routes.
+// Empty host and path and zero port are omitted.
+func RouteURL(host, domain, path string, port int) string {
+	u := domain
+	if host != "" {
+		u = host + "." + u
+	}
+	if port != 0 {
+		u += ":" + strconv.Itoa(port)
+	}
+	return u + path
+}
+
+// ApplicationURLs returns the URLs of all routes mapped to the given
+// application.
+func (c *Client) ApplicationURLs(ctx context.Context, app Application) ([]string, error) {
+	summary, err := c.ApplicationSummary(ctx, app)
+	if err != nil {
+		return nil, err
+	}
+	urls := make([]string, len(summary.Routes))
+	for i, r := range summary.Routes {
+		urls[i] = r.URL()
+	}
+	return urls, nil
+}
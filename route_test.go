@@ -0,0 +1,315 @@
+package ccv2_test
+
+import (
+	"context"
+	"net/http"
+
+	. "github.com/Bo0mer/ccv2"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("Routes", func() {
+	var client *Client
+	var server *ghttp.Server
+
+	var err error
+
+	BeforeEach(func() {
+		client, server = setupTestClientAndServer()
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	Describe("Routes", func() {
+		var routes []Route
+
+		JustBeforeEach(func() {
+			routes, err = client.Routes(context.Background(), Eq(FilterHost, "dora"))
+		})
+
+		Context("when the server returns a valid response", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/v2/routes", "q=host%3Adora"),
+						ghttp.RespondWith(http.StatusOK, `
+{
+    "next_url": null,
+    "resources": [
+        {
+            "metadata": {
+                "guid": "8b4ec76e-5a3b-4a58-9fe5-8f4e9c8c0a77"
+            },
+            "entity": {
+                "host": "dora",
+                "path": "",
+                "domain_guid": "4fd3d5d7-3f9f-4bd4-9a5e-5a2d3b5b3c6e",
+                "space_guid": "1053174d-eb79-4f16-bf82-9f83a52d6e84",
+                "service_instance_guid": null,
+                "port": null
+            }
+        }
+    ]
+}`),
+					),
+				)
+			})
+
+			It("should have returned the routes", func() {
+				Ω(err).ShouldNot(HaveOccurred())
+				Ω(routes).Should(HaveLen(1))
+				Ω(routes[0].GUID).Should(Equal("8b4ec76e-5a3b-4a58-9fe5-8f4e9c8c0a77"))
+				Ω(routes[0].Entity).Should(Equal(RouteEntity{
+					Host:       "dora",
+					DomainGUID: "4fd3d5d7-3f9f-4bd4-9a5e-5a2d3b5b3c6e",
+					SpaceGUID:  "1053174d-eb79-4f16-bf82-9f83a52d6e84",
+				}))
+			})
+		})
+
+		Context("when the server returns a non-2XX response", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(notFoundHandler())
+			})
+
+			It("should have returned a UnexpectedResponseError", func() {
+				Ω(err).Should(beNotFoundErr())
+			})
+		})
+	})
+
+	Describe("ApplicationRoutes", func() {
+		var routes []Route
+
+		JustBeforeEach(func() {
+			app := Application{Metadata: Metadata{GUID: "cd897c8c-3171-456d-b5d7-3c87feeabbd1"}}
+			routes, err = client.ApplicationRoutes(context.Background(), app)
+		})
+
+		BeforeEach(func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/v2/apps/cd897c8c-3171-456d-b5d7-3c87feeabbd1/routes"),
+					ghttp.RespondWith(http.StatusOK, `
+{
+    "next_url": null,
+    "resources": [
+        {
+            "metadata": {
+                "guid": "b6d9b0e1-73b3-4fd8-b53a-0f4f0e1c2d3e"
+            },
+            "entity": {
+                "host": "",
+                "domain_guid": "9a1c3d8e-7f3b-4c1e-8d2a-6b5f4e3d2c1b",
+                "port": 61001
+            }
+        }
+    ]
+}`),
+				),
+			)
+		})
+
+		It("should have returned the routes of the application", func() {
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(routes).Should(HaveLen(1))
+			Ω(routes[0].Entity.Port).Should(Equal(61001))
+		})
+
+		It("should have validated the queries against the sub-collection", func() {
+			app := Application{Metadata: Metadata{GUID: "cd897c8c-3171-456d-b5d7-3c87feeabbd1"}}
+			_, err := client.ApplicationRoutes(context.Background(), app, Eq(FilterOrganizationGUID, "d154425c-dccc-42e6-b6b4-27d46c3b42cb"))
+			Ω(err).Should(MatchError(ContainSubstring(`filter "organization_guid" is not supported by /v2/apps/cd897c8c-3171-456d-b5d7-3c87feeabbd1/routes, ` +
+				"supported filters are: domain_guid, host, path, port")))
+			Ω(server.ReceivedRequests()).Should(HaveLen(1))
+		})
+	})
+
+	Describe("ApplicationRoutes with a GUID that needs escaping", func() {
+		BeforeEach(func() {
+			server.AppendHandlers(
+				ghttp.RespondWith(http.StatusOK, `{"next_url": null, "resources": []}`),
+			)
+		})
+
+		It("should have escaped the GUID", func() {
+			app := Application{Metadata: Metadata{GUID: "a/b"}}
+			_, err := client.ApplicationRoutes(context.Background(), app)
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(server.ReceivedRequests()).Should(HaveLen(1))
+			Ω(server.ReceivedRequests()[0].RequestURI).Should(Equal("/v2/apps/a%2Fb/routes"))
+		})
+	})
+
+	Describe("SharedDomain", func() {
+		var domain SharedDomain
+
+		JustBeforeEach(func() {
+			domain, err = client.SharedDomain(context.Background(), "4fd3d5d7-3f9f-4bd4-9a5e-5a2d3b5b3c6e")
+		})
+
+		BeforeEach(func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/v2/shared_domains/4fd3d5d7-3f9f-4bd4-9a5e-5a2d3b5b3c6e"),
+					ghttp.RespondWith(http.StatusOK, `
+{
+    "metadata": {
+        "guid": "4fd3d5d7-3f9f-4bd4-9a5e-5a2d3b5b3c6e"
+    },
+    "entity": {
+        "name": "tcp.example.com",
+        "router_group_guid": "2d3ec6ae-4d4b-4a2c-8f2c-8d3e3e1b5c6a",
+        "router_group_type": "tcp"
+    }
+}`),
+				),
+			)
+		})
+
+		It("should have returned the shared domain", func() {
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(domain.Entity.Name).Should(Equal("tcp.example.com"))
+			Ω(domain.Entity.RouterGroupGUID).Should(Equal("2d3ec6ae-4d4b-4a2c-8f2c-8d3e3e1b5c6a"))
+			Ω(domain.Entity.RouterGroupType).Should(Equal("tcp"))
+		})
+	})
+
+	Describe("PrivateDomains", func() {
+		var domains []PrivateDomain
+
+		JustBeforeEach(func() {
+			domains, err = client.PrivateDomains(context.Background())
+		})
+
+		BeforeEach(func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/v2/private_domains"),
+					ghttp.RespondWith(http.StatusOK, `
+{
+    "next_url": null,
+    "resources": [
+        {
+            "metadata": {
+                "guid": "0a7e5d1c-2b3f-4a6e-9c8d-7e6f5a4b3c2d"
+            },
+            "entity": {
+                "name": "apps.acme.com",
+                "owning_organization_guid": "d154425c-dccc-42e6-b6b4-27d46c3b42cb"
+            }
+        }
+    ]
+}`),
+				),
+			)
+		})
+
+		It("should have returned the private domains", func() {
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(domains).Should(HaveLen(1))
+			Ω(domains[0].Entity).Should(Equal(PrivateDomainEntity{
+				Name:                   "apps.acme.com",
+				OwningOrganizationGUID: "d154425c-dccc-42e6-b6b4-27d46c3b42cb",
+			}))
+		})
+	})
+
+	Describe("RouteMappings", func() {
+		var mappings []RouteMapping
+
+		JustBeforeEach(func() {
+			mappings, err = client.RouteMappings(context.Background(), Eq(FilterAppGUID, "cd897c8c-3171-456d-b5d7-3c87feeabbd1"))
+		})
+
+		BeforeEach(func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/v2/route_mappings", "q=app_guid%3Acd897c8c-3171-456d-b5d7-3c87feeabbd1"),
+					ghttp.RespondWith(http.StatusOK, `
+{
+    "next_url": null,
+    "resources": [
+        {
+            "metadata": {
+                "guid": "5c8e3f6a-1d2b-4e7f-9a0c-3b4d5e6f7a8b"
+            },
+            "entity": {
+                "app_port": 8080,
+                "app_guid": "cd897c8c-3171-456d-b5d7-3c87feeabbd1",
+                "route_guid": "8b4ec76e-5a3b-4a58-9fe5-8f4e9c8c0a77"
+            }
+        }
+    ]
+}`),
+				),
+			)
+		})
+
+		It("should have returned the route mappings", func() {
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(mappings).Should(HaveLen(1))
+			Ω(mappings[0].Entity).Should(Equal(RouteMappingEntity{
+				AppGUID:   "cd897c8c-3171-456d-b5d7-3c87feeabbd1",
+				RouteGUID: "8b4ec76e-5a3b-4a58-9fe5-8f4e9c8c0a77",
+				AppPort:   8080,
+			}))
+		})
+	})
+
+	Describe("ApplicationURLs", func() {
+		var urls []string
+
+		JustBeforeEach(func() {
+			app := Application{Metadata: Metadata{GUID: "cd897c8c-3171-456d-b5d7-3c87feeabbd1"}}
+			urls, err = client.ApplicationURLs(context.Background(), app)
+		})
+
+		BeforeEach(func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/v2/apps/cd897c8c-3171-456d-b5d7-3c87feeabbd1/summary"),
+					ghttp.RespondWith(http.StatusOK, `
+{
+    "guid": "cd897c8c-3171-456d-b5d7-3c87feeabbd1",
+    "routes": [
+        {
+            "host": "dora",
+            "path": "/api",
+            "port": null,
+            "domain": {"name": "example.com"}
+        },
+        {
+            "host": "",
+            "path": "",
+            "port": 61001,
+            "domain": {"name": "tcp.example.com"}
+        }
+    ]
+}`),
+				),
+			)
+		})
+
+		It("should have returned the URLs of the application", func() {
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(urls).Should(Equal([]string{"dora.example.com/api", "tcp.example.com:61001"}))
+		})
+	})
+})
+
+var _ = DescribeTable("RouteURL",
+	func(host, domain, path string, port int, expected string) {
+		Ω(RouteURL(host, domain, path, port)).Should(Equal(expected))
+	},
+	Entry("host and domain", "dora", "example.com", "", 0, "dora.example.com"),
+	Entry("host, domain and path", "dora", "example.com", "/api/v1", 0, "dora.example.com/api/v1"),
+	Entry("domain only", "", "example.com", "", 0, "example.com"),
+	Entry("domain and port", "", "tcp.example.com", "", 1024, "tcp.example.com:1024"),
+)
@@ -0,0 +1,87 @@
+package ccv2
+
+import (
+	"context"
+	"iter"
+)
+
+// Service represents a service offered in the marketplace.
+type Service = Resource[ServiceEntity]
+
+// ServiceEntity holds the fields of a service.
+type ServiceEntity struct {
+	Label                string   `json:"label"`
+	Provider             string   `json:"provider"`
+	Description          string   `json:"description"`
+	LongDescription      string   `json:"long_description"`
+	Version              string   `json:"version"`
+	InfoURL              string   `json:"info_url"`
+	DocumentationURL     string   `json:"documentation_url"`
+	Active               bool     `json:"active"`
+	Bindable             bool     `json:"bindable"`
+	PlanUpdateable       bool     `json:"plan_updateable"`
+	InstancesRetrievable bool     `json:"instances_retrievable"`
+	BindingsRetrievable  bool     `json:"bindings_retrievable"`
+	UniqueID             string   `json:"unique_id"`
+	Tags                 []string `json:"tags"`
+	Requires             []string `json:"requires"`
+	ServiceBrokerGUID    string   `json:"service_broker_guid"`
+	// ExtraJSON is the extra field of the entity, a JSON document with
+	// broker specific metadata. It is unrelated to Resource.Extra, which
+	// holds the entity fields that are not declared here.
+	ExtraJSON string `json:"extra"`
+}
+
+// Services list all services that conform to the provided queries.
+func (c *Client) Services(ctx context.Context, queries ...Query) ([]Service, error) {
+	return ListResources[ServiceEntity](ctx, c, "/v2/services", queries...)
+}
+
+// EachService returns an iterator over all services that conform to the
+// provided queries. Unlike Services, it holds at most one page in memory and
+// stops requesting pages as soon as the loop is exited.
+func (c *Client) EachService(ctx context.Context, queries ...Query) iter.Seq2[Service, error] {
+	return EachResource[ServiceEntity](ctx, c, "/v2/services", queries...)
+}
+
+// Service returns the service with the given GUID.
+func (c *Client) Service(ctx context.Context, guid string) (Service, error) {
+	return GetResource[ServiceEntity](ctx, c, "/v2/services", guid)
+}
+
+// ServicePlan represents a plan of a service.
+type ServicePlan = Resource[ServicePlanEntity]
+
+// ServicePlanEntity holds the fields of a service plan.
+type ServicePlanEntity struct {
+	Name           string `json:"name"`
+	Description    string `json:"description"`
+	ServiceGUID    string `json:"service_guid"`
+	Free           bool   `json:"free"`
+	Public         bool   `json:"public"`
+	Active         bool   `json:"active"`
+	Bindable       bool   `json:"bindable"`
+	PlanUpdateable bool   `json:"plan_updateable"`
+	UniqueID       string `json:"unique_id"`
+	// ExtraJSON is the extra field of the entity, a JSON document with
+	// broker specific metadata. It is unrelated to Resource.Extra, which
+	// holds the entity fields that are not declared here.
+	ExtraJSON string `json:"extra"`
+}
+
+// ServicePlans list all service plans that conform to the provided queries.
+func (c *Client) ServicePlans(ctx context.Context, queries ...Query) ([]ServicePlan, error) {
+	return ListResources[ServicePlanEntity](ctx, c, "/v2/service_plans", queries...)
+}
+
+// EachServicePlan returns an iterator over all service plans that conform to
+// the provided queries. Unlike ServicePlans, it holds at most one page in
+// memory and stops requesting pages as soon as the loop is exited.
+func (c *Client) EachServicePlan(ctx context.Context, queries ...Query) iter.Seq2[ServicePlan, error] {
+	return EachResource[ServicePlanEntity](ctx, c, "/v2/service_plans", queries...)
+}
+
+// ServicePlan returns the service plan with the given GUID.
+func (c *Client) ServicePlan(ctx context.Context, guid string) (ServicePlan, error) {
+	return GetResource[ServicePlanEntity](ctx, c, "/v2/service_plans", guid)
+}
@@ -0,0 +1,174 @@
+package ccv2_test
+
+import (
+	"context"
+	"net/http"
+
+	. "github.com/Bo0mer/ccv2"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("Services", func() {
+	var client *Client
+	var server *ghttp.Server
+
+	var err error
+
+	BeforeEach(func() {
+		client, server = setupTestClientAndServer()
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	Describe("Services", func() {
+		var services []Service
+
+		JustBeforeEach(func() {
+			services, err = client.Services(context.Background(), Eq(FilterLabel, "postgres"))
+		})
+
+		Context("when the server returns a valid response", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/v2/services", "q=label%3Apostgres"),
+						ghttp.RespondWith(http.StatusOK, `
+{
+    "next_url": null,
+    "resources": [
+        {
+            "metadata": {
+                "guid": "1a2b3c4d-5e6f-4a8b-9c0d-1e2f3a4b5c6d"
+            },
+            "entity": {
+                "label": "postgres",
+                "provider": null,
+                "url": null,
+                "description": "PostgreSQL databases on demand",
+                "long_description": null,
+                "version": null,
+                "info_url": null,
+                "active": true,
+                "bindable": true,
+                "unique_id": "b2c3d4e5-postgres",
+                "extra": "{\"displayName\":\"PostgreSQL\"}",
+                "tags": ["relational", "sql"],
+                "requires": [],
+                "documentation_url": null,
+                "service_broker_guid": "9f8e7d6c-5b4a-4938-8271-605f4e3d2c1b",
+                "plan_updateable": true,
+                "bindings_retrievable": false,
+                "instances_retrievable": false
+            }
+        }
+    ]
+}`),
+					),
+				)
+			})
+
+			It("should have returned the services", func() {
+				Ω(err).ShouldNot(HaveOccurred())
+				Ω(services).Should(HaveLen(1))
+				Ω(services[0].Entity).Should(Equal(ServiceEntity{
+					Label:             "postgres",
+					Description:       "PostgreSQL databases on demand",
+					Active:            true,
+					Bindable:          true,
+					PlanUpdateable:    true,
+					UniqueID:          "b2c3d4e5-postgres",
+					Tags:              []string{"relational", "sql"},
+					Requires:          []string{},
+					ServiceBrokerGUID: "9f8e7d6c-5b4a-4938-8271-605f4e3d2c1b",
+					ExtraJSON:         `{"displayName":"PostgreSQL"}`,
+				}))
+				Ω(services[0].Extra).ShouldNot(HaveKey("extra"))
+			})
+		})
+
+		Context("when the server returns a non-2XX response", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(notFoundHandler())
+			})
+
+			It("should have returned a UnexpectedResponseError", func() {
+				Ω(err).Should(beNotFoundErr())
+			})
+		})
+	})
+
+	Describe("ServicePlans", func() {
+		var plans []ServicePlan
+
+		JustBeforeEach(func() {
+			plans, err = client.ServicePlans(context.Background(), Eq(FilterServiceGUID, "1a2b3c4d-5e6f-4a8b-9c0d-1e2f3a4b5c6d"))
+		})
+
+		BeforeEach(func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/v2/service_plans", "q=service_guid%3A1a2b3c4d-5e6f-4a8b-9c0d-1e2f3a4b5c6d"),
+					ghttp.RespondWith(http.StatusOK, `
+{
+    "next_url": null,
+    "resources": [
+        {
+            "metadata": {
+                "guid": "7e6d5c4b-3a29-4180-9f8e-7d6c5b4a3928"
+            },
+            "entity": {
+                "name": "small",
+                "free": false,
+                "description": "A small database",
+                "service_guid": "1a2b3c4d-5e6f-4a8b-9c0d-1e2f3a4b5c6d",
+                "extra": null,
+                "unique_id": "postgres-small",
+                "public": true,
+                "bindable": true,
+                "active": true,
+                "plan_updateable": false
+            }
+        }
+    ]
+}`),
+				),
+			)
+		})
+
+		It("should have returned the service plans", func() {
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(plans).Should(HaveLen(1))
+			Ω(plans[0].Entity).Should(Equal(ServicePlanEntity{
+				Name:        "small",
+				Description: "A small database",
+				ServiceGUID: "1a2b3c4d-5e6f-4a8b-9c0d-1e2f3a4b5c6d",
+				Public:      true,
+				Active:      true,
+				Bindable:    true,
+				UniqueID:    "postgres-small",
+			}))
+		})
+	})
+
+	Describe("ServicePlan", func() {
+		var plan ServicePlan
+
+		JustBeforeEach(func() {
+			plan, err = client.ServicePlan(context.Background(), "7e6d5c4b-3a29-4180-9f8e-7d6c5b4a3928")
+		})
+
+		BeforeEach(func() {
+			server.AppendHandlers(notFoundHandler())
+		})
+
+		It("should have returned a not found error", func() {
+			Ω(err).Should(beNotFoundErr())
+			Ω(plan).Should(BeZero())
+		})
+	})
+})
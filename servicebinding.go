@@ -0,0 +1,67 @@
+package ccv2
+
+import (
+	"context"
+	"iter"
+)
+
+// ServiceBinding represents the binding of a service instance to an
+// application.
+type ServiceBinding = Resource[ServiceBindingEntity]
+
+// ServiceBindingEntity holds the fields of a service binding.
+type ServiceBindingEntity struct {
+	Name                string                 `json:"name"`
+	AppGUID             string                 `json:"app_guid"`
+	ServiceInstanceGUID string                 `json:"service_instance_guid"`
+	Credentials         map[string]interface{} `json:"credentials"`
+	BindingOptions      map[string]interface{} `json:"binding_options"`
+	GatewayName         string                 `json:"gateway_name"`
+	SyslogDrainURL      string                 `json:"syslog_drain_url"`
+}
+
+// ServiceBindings list all service bindings that conform to the provided
+// queries.
+func (c *Client) ServiceBindings(ctx context.Context, queries ...Query) ([]ServiceBinding, error) {
+	return ListResources[ServiceBindingEntity](ctx, c, "/v2/service_bindings", queries...)
+}
+
+// EachServiceBinding returns an iterator over all service bindings that
+// conform to the provided queries. Unlike ServiceBindings, it holds at most
+// one page in memory and stops requesting pages as soon as the loop is exited.
+func (c *Client) EachServiceBinding(ctx context.Context, queries ...Query) iter.Seq2[ServiceBinding, error] {
+	return EachResource[ServiceBindingEntity](ctx, c, "/v2/service_bindings", queries...)
+}
+
+// ServiceBinding returns the service binding with the given GUID.
+func (c *Client) ServiceBinding(ctx context.Context, guid string) (ServiceBinding, error) {
+	return GetResource[ServiceBindingEntity](ctx, c, "/v2/service_bindings", guid)
+}
+
+// ServiceKey represents a set of credentials for a service instance that is
+// not bound to an application.
+type ServiceKey = Resource[ServiceKeyEntity]
+
+// ServiceKeyEntity holds the fields of a service key.
+type ServiceKeyEntity struct {
+	Name                string                 `json:"name"`
+	ServiceInstanceGUID string                 `json:"service_instance_guid"`
+	Credentials         map[string]interface{} `json:"credentials"`
+}
+
+// ServiceKeys list all service keys that conform to the provided queries.
+func (c *Client) ServiceKeys(ctx context.Context, queries ...Query) ([]ServiceKey, error) {
+	return ListResources[ServiceKeyEntity](ctx, c, "/v2/service_keys", queries...)
+}
+
+// EachServiceKey returns an iterator over all service keys that conform to
+// the provided queries. Unlike ServiceKeys, it holds at most one page in
+// memory and stops requesting pages as soon as the loop is exited.
+func (c *Client) EachServiceKey(ctx context.Context, queries ...Query) iter.Seq2[ServiceKey, error] {
+	return EachResource[ServiceKeyEntity](ctx, c, "/v2/service_keys", queries...)
+}
+
+// ServiceKey returns the service key with the given GUID.
+func (c *Client) ServiceKey(ctx context.Context, guid string) (ServiceKey, error) {
+	return GetResource[ServiceKeyEntity](ctx, c, "/v2/service_keys", guid)
+}
@@ -0,0 +1,130 @@
+package ccv2_test
+
+import (
+	"context"
+	"net/http"
+
+	. "github.com/Bo0mer/ccv2"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("ServiceBindings", func() {
+	var client *Client
+	var server *ghttp.Server
+
+	var err error
+
+	BeforeEach(func() {
+		client, server = setupTestClientAndServer()
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	Describe("ServiceBindings", func() {
+		var bindings []ServiceBinding
+
+		JustBeforeEach(func() {
+			bindings, err = client.ServiceBindings(context.Background(), Eq(FilterServiceInstanceGUID, "b8f3d3a2-2f5e-4b7e-9c1d-0e4a6f7b8c9d"))
+		})
+
+		Context("when the server returns a valid response", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/v2/service_bindings", "q=service_instance_guid%3Ab8f3d3a2-2f5e-4b7e-9c1d-0e4a6f7b8c9d"),
+						ghttp.RespondWith(http.StatusOK, `
+{
+    "next_url": null,
+    "resources": [
+        {
+            "metadata": {
+                "guid": "3c2b1a09-8f7e-4d6c-b5a4-938271605f4e"
+            },
+            "entity": {
+                "app_guid": "cd897c8c-3171-456d-b5d7-3c87feeabbd1",
+                "service_instance_guid": "b8f3d3a2-2f5e-4b7e-9c1d-0e4a6f7b8c9d",
+                "credentials": {"username": "orders"},
+                "binding_options": {},
+                "gateway_data": null,
+                "gateway_name": "",
+                "syslog_drain_url": null,
+                "volume_mounts": [],
+                "name": null
+            }
+        }
+    ]
+}`),
+					),
+				)
+			})
+
+			It("should have returned the service bindings", func() {
+				Ω(err).ShouldNot(HaveOccurred())
+				Ω(bindings).Should(HaveLen(1))
+				Ω(bindings[0].Entity).Should(Equal(ServiceBindingEntity{
+					AppGUID:             "cd897c8c-3171-456d-b5d7-3c87feeabbd1",
+					ServiceInstanceGUID: "b8f3d3a2-2f5e-4b7e-9c1d-0e4a6f7b8c9d",
+					Credentials:         map[string]interface{}{"username": "orders"},
+					BindingOptions:      map[string]interface{}{},
+				}))
+			})
+		})
+
+		Context("when the server returns a non-2XX response", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(notFoundHandler())
+			})
+
+			It("should have returned a UnexpectedResponseError", func() {
+				Ω(err).Should(beNotFoundErr())
+			})
+		})
+	})
+
+	Describe("ServiceKeys", func() {
+		var keys []ServiceKey
+
+		JustBeforeEach(func() {
+			keys, err = client.ServiceKeys(context.Background(), Eq(FilterName, "reporting"))
+		})
+
+		BeforeEach(func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/v2/service_keys", "q=name%3Areporting"),
+					ghttp.RespondWith(http.StatusOK, `
+{
+    "next_url": null,
+    "resources": [
+        {
+            "metadata": {
+                "guid": "6f5e4d3c-2b1a-4098-8f7e-6d5c4b3a2918"
+            },
+            "entity": {
+                "name": "reporting",
+                "service_instance_guid": "b8f3d3a2-2f5e-4b7e-9c1d-0e4a6f7b8c9d",
+                "credentials": {"username": "reporting"}
+            }
+        }
+    ]
+}`),
+				),
+			)
+		})
+
+		It("should have returned the service keys", func() {
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(keys).Should(HaveLen(1))
+			Ω(keys[0].Entity).Should(Equal(ServiceKeyEntity{
+				Name:                "reporting",
+				ServiceInstanceGUID: "b8f3d3a2-2f5e-4b7e-9c1d-0e4a6f7b8c9d",
+				Credentials:         map[string]interface{}{"username": "reporting"},
+			}))
+		})
+	})
+})
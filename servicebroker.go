@@ -0,0 +1,122 @@
+package ccv2
+
+import (
+	"context"
+	"iter"
+
+	"github.com/pkg/errors"
+)
+
+// ServiceBroker represents a broker that provides services to the
+// marketplace.
+type ServiceBroker = Resource[ServiceBrokerEntity]
+
+// ServiceBrokerEntity holds the fields of a service broker.
+type ServiceBrokerEntity struct {
+	Name         string `json:"name"`
+	BrokerURL    string `json:"broker_url"`
+	AuthUsername string `json:"auth_username"`
+	// SpaceGUID is set only for brokers whose services are available in a
+	// single space.
+	SpaceGUID string `json:"space_guid"`
+}
+
+// ServiceBrokers list all service brokers that conform to the provided
+// queries.
+func (c *Client) ServiceBrokers(ctx context.Context, queries ...Query) ([]ServiceBroker, error) {
+	return ListResources[ServiceBrokerEntity](ctx, c, "/v2/service_brokers", queries...)
+}
+
+// EachServiceBroker returns an iterator over all service brokers that conform
+// to the provided queries. Unlike ServiceBrokers, it holds at most one page in
+// memory and stops requesting pages as soon as the loop is exited.
+func (c *Client) EachServiceBroker(ctx context.Context, queries ...Query) iter.Seq2[ServiceBroker, error] {
+	return EachResource[ServiceBrokerEntity](ctx, c, "/v2/service_brokers", queries...)
+}
+
+// ServiceBroker returns the service broker with the given GUID.
+func (c *Client) ServiceBroker(ctx context.Context, guid string) (ServiceBroker, error) {
+	return GetResource[ServiceBrokerEntity](ctx, c, "/v2/service_brokers", guid)
+}
+
+// ServicePlanVisibility represents the visibility of a non-public service
+// plan to an organization.
+type ServicePlanVisibility = Resource[ServicePlanVisibilityEntity]
+
+// ServicePlanVisibilityEntity holds the fields of a service plan visibility.
+type ServicePlanVisibilityEntity struct {
+	ServicePlanGUID  string `json:"service_plan_guid"`
+	OrganizationGUID string `json:"organization_guid"`
+}
+
+// ServicePlanVisibilities list all service plan visibilities that conform to
+// the provided queries.
+func (c *Client) ServicePlanVisibilities(ctx context.Context, queries ...Query) ([]ServicePlanVisibility, error) {
+	return ListResources[ServicePlanVisibilityEntity](ctx, c, "/v2/service_plan_visibilities", queries...)
+}
+
+// EachServicePlanVisibility returns an iterator over all service plan
+// visibilities that conform to the provided queries. Unlike
+// ServicePlanVisibilities, it holds at most one page in memory and stops
+// requesting pages as soon as the loop is exited.
+func (c *Client) EachServicePlanVisibility(ctx context.Context, queries ...Query) iter.Seq2[ServicePlanVisibility, error] {
+	return EachResource[ServicePlanVisibilityEntity](ctx, c, "/v2/service_plan_visibilities", queries...)
+}
+
+// ServicePlanVisibility returns the service plan visibility with the given
+// GUID.
+func (c *Client) ServicePlanVisibility(ctx context.Context, guid string) (ServicePlanVisibility, error) {
+	return GetResource[ServicePlanVisibilityEntity](ctx, c, "/v2/service_plan_visibilities", guid)
+}
+
+// OrganizationPlans holds the service plans that are visible to an
+// organization.
+type OrganizationPlans struct {
+	Organization Organization
+	// Plans are the public plans and the plans made visible to the
+	// organization explicitly, in the order in which the Cloud Controller
+	// lists them.
+	Plans []ServicePlan
+}
+
+// EffectivePlanVisibility returns the service plans visible to each
+// organization, keyed by organization GUID. A plan is visible to an
+// organization if it is public or if a service plan visibility grants the
+// organization access to it.
+//
+// Plans of space scoped brokers are neither public nor granted through
+// visibilities, hence they are not included.
+func (c *Client) EffectivePlanVisibility(ctx context.Context) (map[string]OrganizationPlans, error) {
+	orgs, err := c.Organizations(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "listing organizations failed")
+	}
+	plans, err := c.ServicePlans(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "listing service plans failed")
+	}
+	visibilities, err := c.ServicePlanVisibilities(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "listing service plan visibilities failed")
+	}
+
+	granted := make(map[string]map[string]bool)
+	for _, v := range visibilities {
+		if granted[v.Entity.OrganizationGUID] == nil {
+			granted[v.Entity.OrganizationGUID] = make(map[string]bool)
+		}
+		granted[v.Entity.OrganizationGUID][v.Entity.ServicePlanGUID] = true
+	}
+
+	result := make(map[string]OrganizationPlans, len(orgs))
+	for _, org := range orgs {
+		visible := OrganizationPlans{Organization: org}
+		for _, plan := range plans {
+			if plan.Entity.Public || granted[org.GUID][plan.GUID] {
+				visible.Plans = append(visible.Plans, plan)
+			}
+		}
+		result[org.GUID] = visible
+	}
+	return result, nil
+}
@@ -0,0 +1,202 @@
+package ccv2_test
+
+import (
+	"context"
+	"net/http"
+
+	. "github.com/Bo0mer/ccv2"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("ServiceBrokers", func() {
+	var client *Client
+	var server *ghttp.Server
+
+	var err error
+
+	BeforeEach(func() {
+		client, server = setupTestClientAndServer()
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	Describe("ServiceBrokers", func() {
+		var brokers []ServiceBroker
+
+		JustBeforeEach(func() {
+			brokers, err = client.ServiceBrokers(context.Background())
+		})
+
+		Context("when the server returns a valid response", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/v2/service_brokers"),
+						ghttp.RespondWith(http.StatusOK, `
+{
+    "next_url": null,
+    "resources": [
+        {
+            "metadata": {
+                "guid": "9f8e7d6c-5b4a-4938-8271-605f4e3d2c1b"
+            },
+            "entity": {
+                "name": "postgres-broker",
+                "broker_url": "https://postgres-broker.example.com",
+                "auth_username": "admin",
+                "space_guid": null
+            }
+        }
+    ]
+}`),
+					),
+				)
+			})
+
+			It("should have returned the service brokers", func() {
+				Ω(err).ShouldNot(HaveOccurred())
+				Ω(brokers).Should(HaveLen(1))
+				Ω(brokers[0].Entity).Should(Equal(ServiceBrokerEntity{
+					Name:         "postgres-broker",
+					BrokerURL:    "https://postgres-broker.example.com",
+					AuthUsername: "admin",
+				}))
+			})
+		})
+
+		Context("when the server returns a non-2XX response", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(notFoundHandler())
+			})
+
+			It("should have returned a UnexpectedResponseError", func() {
+				Ω(err).Should(beNotFoundErr())
+			})
+		})
+	})
+
+	Describe("ServicePlanVisibilities", func() {
+		var visibilities []ServicePlanVisibility
+
+		JustBeforeEach(func() {
+			visibilities, err = client.ServicePlanVisibilities(context.Background(), Eq(FilterOrganizationGUID, "d154425c-dccc-42e6-b6b4-27d46c3b42cb"))
+		})
+
+		BeforeEach(func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/v2/service_plan_visibilities", "q=organization_guid%3Ad154425c-dccc-42e6-b6b4-27d46c3b42cb"),
+					ghttp.RespondWith(http.StatusOK, visibilitiesResponse),
+				),
+			)
+		})
+
+		It("should have returned the service plan visibilities", func() {
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(visibilities).Should(HaveLen(1))
+			Ω(visibilities[0].Entity).Should(Equal(ServicePlanVisibilityEntity{
+				ServicePlanGUID:  "c3b2a190-8f7e-4d6c-b5a4-93827160f5e4",
+				OrganizationGUID: "d154425c-dccc-42e6-b6b4-27d46c3b42cb",
+			}))
+		})
+	})
+
+	Describe("EffectivePlanVisibility", func() {
+		var visibility map[string]OrganizationPlans
+
+		JustBeforeEach(func() {
+			visibility, err = client.EffectivePlanVisibility(context.Background())
+		})
+
+		Context("when the server returns valid responses", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/v2/organizations"),
+						ghttp.RespondWith(http.StatusOK, `
+{
+    "next_url": null,
+    "resources": [
+        {"metadata": {"guid": "d154425c-dccc-42e6-b6b4-27d46c3b42cb"}, "entity": {"name": "acme"}},
+        {"metadata": {"guid": "5e4d3c2b-1a09-4f8e-9d7c-6b5a49382716"}, "entity": {"name": "globex"}}
+    ]
+}`),
+					),
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/v2/service_plans"),
+						ghttp.RespondWith(http.StatusOK, `
+{
+    "next_url": null,
+    "resources": [
+        {"metadata": {"guid": "7e6d5c4b-3a29-4180-9f8e-7d6c5b4a3928"}, "entity": {"name": "small", "public": true}},
+        {"metadata": {"guid": "c3b2a190-8f7e-4d6c-b5a4-93827160f5e4"}, "entity": {"name": "large", "public": false}},
+        {"metadata": {"guid": "0f1e2d3c-4b5a-4697-8877-665544332211"}, "entity": {"name": "beta", "public": false}}
+    ]
+}`),
+					),
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/v2/service_plan_visibilities"),
+						ghttp.RespondWith(http.StatusOK, visibilitiesResponse),
+					),
+				)
+			})
+
+			It("should have returned the public plans for every organization", func() {
+				Ω(err).ShouldNot(HaveOccurred())
+				Ω(visibility).Should(HaveLen(2))
+				globex := visibility["5e4d3c2b-1a09-4f8e-9d7c-6b5a49382716"]
+				Ω(globex.Organization.Entity.Name).Should(Equal("globex"))
+				Ω(planNames(globex.Plans)).Should(Equal([]string{"small"}))
+			})
+
+			It("should have returned the explicitly visible plans", func() {
+				acme := visibility["d154425c-dccc-42e6-b6b4-27d46c3b42cb"]
+				Ω(acme.Organization.Entity.Name).Should(Equal("acme"))
+				Ω(planNames(acme.Plans)).Should(Equal([]string{"small", "large"}))
+			})
+		})
+
+		Context("when listing the service plans fails", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.RespondWith(http.StatusOK, `{"next_url": null, "resources": []}`),
+					notFoundHandler(),
+				)
+			})
+
+			It("should have returned an error", func() {
+				Ω(err).Should(MatchError(ContainSubstring("listing service plans failed")))
+				Ω(visibility).Should(BeNil())
+			})
+		})
+	})
+})
+
+const visibilitiesResponse = `
+{
+    "next_url": null,
+    "resources": [
+        {
+            "metadata": {
+                "guid": "a1b2c3d4-e5f6-4789-8abc-def012345678"
+            },
+            "entity": {
+                "service_plan_guid": "c3b2a190-8f7e-4d6c-b5a4-93827160f5e4",
+                "organization_guid": "d154425c-dccc-42e6-b6b4-27d46c3b42cb"
+            }
+        }
+    ]
+}`
+
+func planNames(plans []ServicePlan) []string {
+	names := make([]string, len(plans))
+	for i, p := range plans {
+		names[i] = p.Entity.Name
+	}
+	return names
+}
@@ -0,0 +1,124 @@
+package ccv2
+
+import (
+	"context"
+	"iter"
+	"time"
+)
+
+// ServiceInstance represents an instance of a managed service.
+type ServiceInstance = Resource[ServiceInstanceEntity]
+
+// ServiceInstanceEntity holds the fields of a managed service instance.
+type ServiceInstanceEntity struct {
+	Name            string                 `json:"name"`
+	Type            string                 `json:"type"`
+	SpaceGUID       string                 `json:"space_guid"`
+	ServiceGUID     string                 `json:"service_guid"`
+	ServicePlanGUID string                 `json:"service_plan_guid"`
+	Credentials     map[string]interface{} `json:"credentials"`
+	DashboardURL    string                 `json:"dashboard_url"`
+	Tags            []string               `json:"tags"`
+	LastOperation   LastOperation          `json:"last_operation"`
+}
+
+// LastOperation describes the last asynchronous operation performed on a
+// service instance.
+type LastOperation struct {
+	// Type is one of create, update or delete.
+	Type string `json:"type"`
+	// State is one of in progress, succeeded or failed.
+	State       string    `json:"state"`
+	Description string    `json:"description"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// ServiceInstances list all managed service instances that conform to the
+// provided queries.
+func (c *Client) ServiceInstances(ctx context.Context, queries ...Query) ([]ServiceInstance, error) {
+	return ListResources[ServiceInstanceEntity](ctx, c, "/v2/service_instances", queries...)
+}
+
+// EachServiceInstance returns an iterator over all managed service instances
+// that conform to the provided queries. Unlike ServiceInstances, it holds at
+// most one page in memory and stops requesting pages as soon as the loop is
+// exited.
+func (c *Client) EachServiceInstance(ctx context.Context, queries ...Query) iter.Seq2[ServiceInstance, error] {
+	return EachResource[ServiceInstanceEntity](ctx, c, "/v2/service_instances", queries...)
+}
+
+// ServiceInstance returns the managed service instance with the given GUID.
+func (c *Client) ServiceInstance(ctx context.Context, guid string) (ServiceInstance, error) {
+	return GetResource[ServiceInstanceEntity](ctx, c, "/v2/service_instances", guid)
+}
+
+// UserProvidedServiceInstance represents a service instance that is not
+// backed by a service broker.
+type UserProvidedServiceInstance = Resource[UserProvidedServiceInstanceEntity]
+
+// UserProvidedServiceInstanceEntity holds the fields of a user provided
+// service instance.
+type UserProvidedServiceInstanceEntity struct {
+	Name            string                 `json:"name"`
+	Type            string                 `json:"type"`
+	SpaceGUID       string                 `json:"space_guid"`
+	Credentials     map[string]interface{} `json:"credentials"`
+	SyslogDrainURL  string                 `json:"syslog_drain_url"`
+	RouteServiceURL string                 `json:"route_service_url"`
+	Tags            []string               `json:"tags"`
+}
+
+// UserProvidedServiceInstances list all user provided service instances that
+// conform to the provided queries.
+func (c *Client) UserProvidedServiceInstances(ctx context.Context, queries ...Query) ([]UserProvidedServiceInstance, error) {
+	return ListResources[UserProvidedServiceInstanceEntity](ctx, c, "/v2/user_provided_service_instances", queries...)
+}
+
+// EachUserProvidedServiceInstance returns an iterator over all user provided
+// service instances that conform to the provided queries. Unlike
+// UserProvidedServiceInstances, it holds at most one page in memory and stops
+// requesting pages as soon as the loop is exited.
+func (c *Client) EachUserProvidedServiceInstance(ctx context.Context, queries ...Query) iter.Seq2[UserProvidedServiceInstance, error] {
+	return EachResource[UserProvidedServiceInstanceEntity](ctx, c, "/v2/user_provided_service_instances", queries...)
+}
+
+// UserProvidedServiceInstance returns the user provided service instance with
+// the given GUID.
+func (c *Client) UserProvidedServiceInstance(ctx context.Context, guid string) (UserProvidedServiceInstance, error) {
+	return GetResource[UserProvidedServiceInstanceEntity](ctx, c, "/v2/user_provided_service_instances", guid)
+}
+
+// ServiceInstanceSummary represents a service instance bound to an
+// application, as included in an application summary.
+type ServiceInstanceSummary struct {
+	GUID          string        `json:"guid"`
+	Name          string        `json:"name"`
+	BoundAppCount int           `json:"bound_app_count"`
+	DashboardURL  string        `json:"dashboard_url"`
+	LastOperation LastOperation `json:"last_operation"`
+	// ServicePlan is empty for user provided service instances.
+	ServicePlan ServicePlanSummary `json:"service_plan"`
+}
+
+// IsUserProvided reports whether the service instance is a user provided one.
+func (s ServiceInstanceSummary) IsUserProvided() bool {
+	return s.ServicePlan.GUID == ""
+}
+
+// ServicePlanSummary represents the plan of a service instance included in an
+// application summary.
+type ServicePlanSummary struct {
+	GUID    string         `json:"guid"`
+	Name    string         `json:"name"`
+	Service ServiceSummary `json:"service"`
+}
+
+// ServiceSummary represents the service of a service plan included in an
+// application summary.
+type ServiceSummary struct {
+	GUID     string `json:"guid"`
+	Label    string `json:"label"`
+	Provider string `json:"provider"`
+	Version  string `json:"version"`
+}
@@ -0,0 +1,146 @@
+package ccv2_test
+
+import (
+	"context"
+	"net/http"
+
+	. "github.com/Bo0mer/ccv2"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("ServiceInstances", func() {
+	var client *Client
+	var server *ghttp.Server
+
+	var err error
+
+	BeforeEach(func() {
+		client, server = setupTestClientAndServer()
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	Describe("ServiceInstances", func() {
+		var instances []ServiceInstance
+
+		JustBeforeEach(func() {
+			instances, err = client.ServiceInstances(context.Background(), Eq(FilterSpaceGUID, "1053174d-eb79-4f16-bf82-9f83a52d6e84"))
+		})
+
+		Context("when the server returns a valid response", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/v2/service_instances", "q=space_guid%3A1053174d-eb79-4f16-bf82-9f83a52d6e84"),
+						ghttp.RespondWith(http.StatusOK, `
+{
+    "next_url": null,
+    "resources": [
+        {
+            "metadata": {
+                "guid": "b8f3d3a2-2f5e-4b7e-9c1d-0e4a6f7b8c9d"
+            },
+            "entity": {
+                "name": "orders-db",
+                "credentials": {"uri": "postgres://db.example.com/orders"},
+                "service_plan_guid": "7e6d5c4b-3a29-4180-9f8e-7d6c5b4a3928",
+                "space_guid": "1053174d-eb79-4f16-bf82-9f83a52d6e84",
+                "gateway_data": null,
+                "dashboard_url": "https://db.example.com/dashboard",
+                "type": "managed_service_instance",
+                "last_operation": {
+                    "type": "update",
+                    "state": "in progress",
+                    "description": "Resizing",
+                    "updated_at": "2016-06-08T16:41:40Z",
+                    "created_at": "2016-06-08T16:41:30Z"
+                },
+                "tags": ["orders"],
+                "service_guid": "1a2b3c4d-5e6f-4a8b-9c0d-1e2f3a4b5c6d"
+            }
+        }
+    ]
+}`),
+					),
+				)
+			})
+
+			It("should have returned the service instances", func() {
+				Ω(err).ShouldNot(HaveOccurred())
+				Ω(instances).Should(HaveLen(1))
+				Ω(instances[0].Entity).Should(Equal(ServiceInstanceEntity{
+					Name:            "orders-db",
+					Type:            "managed_service_instance",
+					SpaceGUID:       "1053174d-eb79-4f16-bf82-9f83a52d6e84",
+					ServiceGUID:     "1a2b3c4d-5e6f-4a8b-9c0d-1e2f3a4b5c6d",
+					ServicePlanGUID: "7e6d5c4b-3a29-4180-9f8e-7d6c5b4a3928",
+					Credentials:     map[string]interface{}{"uri": "postgres://db.example.com/orders"},
+					DashboardURL:    "https://db.example.com/dashboard",
+					Tags:            []string{"orders"},
+					LastOperation: LastOperation{
+						Type:        "update",
+						State:       "in progress",
+						Description: "Resizing",
+						CreatedAt:   parseTime("2016-06-08T16:41:30Z"),
+						UpdatedAt:   parseTime("2016-06-08T16:41:40Z"),
+					},
+				}))
+				Ω(instances[0].Extra).Should(HaveKey("gateway_data"))
+			})
+		})
+
+		Context("when the server returns a non-2XX response", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(notFoundHandler())
+			})
+
+			It("should have returned a UnexpectedResponseError", func() {
+				Ω(err).Should(beNotFoundErr())
+			})
+		})
+	})
+
+	Describe("UserProvidedServiceInstance", func() {
+		var instance UserProvidedServiceInstance
+
+		JustBeforeEach(func() {
+			instance, err = client.UserProvidedServiceInstance(context.Background(), "e2d1c0b9-a8f7-4e6d-8c5b-4a3928170615")
+		})
+
+		BeforeEach(func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/v2/user_provided_service_instances/e2d1c0b9-a8f7-4e6d-8c5b-4a3928170615"),
+					ghttp.RespondWith(http.StatusOK, `
+{
+    "metadata": {
+        "guid": "e2d1c0b9-a8f7-4e6d-8c5b-4a3928170615"
+    },
+    "entity": {
+        "name": "log-drain",
+        "credentials": {},
+        "space_guid": "1053174d-eb79-4f16-bf82-9f83a52d6e84",
+        "type": "user_provided_service_instance",
+        "syslog_drain_url": "syslog://logs.example.com:514",
+        "route_service_url": "",
+        "tags": []
+    }
+}`),
+				),
+			)
+		})
+
+		It("should have returned the user provided service instance", func() {
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(instance.GUID).Should(Equal("e2d1c0b9-a8f7-4e6d-8c5b-4a3928170615"))
+			Ω(instance.Entity.Name).Should(Equal("log-drain"))
+			Ω(instance.Entity.Type).Should(Equal("user_provided_service_instance"))
+			Ω(instance.Entity.SyslogDrainURL).Should(Equal("syslog://logs.example.com:514"))
+		})
+	})
+})
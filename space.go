@@ -1,20 +1,34 @@
 package ccv2
 
-// Metadata represents metadata for a resource.
-type Metadata struct {
-	GUID      string `json:"guid"`
-	CreatedAt string `json:"created_at"`
-	UpdatedAt string `json:"updated_at"`
-}
+import (
+	"context"
+	"iter"
+)
 
 // Space represents a Cloud Foundry space.
-type Space struct {
-	Metadata `json:"metadata"`
+type Space = Resource[SpaceEntity]
+
+// SpaceEntity holds the fields of a space.
+type SpaceEntity struct {
+	Name                     string `json:"name"`
+	OrganizationGUID         string `json:"organization_guid"`
+	SpaceQuotaDefinitionGUID string `json:"space_quota_definition_guid"`
+	AllowSSH                 bool   `json:"allow_ssh"`
+}
+
+// Spaces list all spaces that conform to the provided queries.
+func (c *Client) Spaces(ctx context.Context, queries ...Query) ([]Space, error) {
+	return ListResources[SpaceEntity](ctx, c, "/v2/spaces", queries...)
+}
+
+// EachSpace returns an iterator over all spaces that conform to the provided
+// queries. Unlike Spaces, it holds at most one page in memory and stops
+// requesting pages as soon as the loop is exited.
+func (c *Client) EachSpace(ctx context.Context, queries ...Query) iter.Seq2[Space, error] {
+	return EachResource[SpaceEntity](ctx, c, "/v2/spaces", queries...)
+}
 
-	Entity struct {
-		Name                    string `json:"name"`
-		OrganizationGUID        string `json:"organization_guid"`
-		SpaceQuotaDefinitonGUID string `json:"space_quota_definiton_guid"`
-		AllowSSH                bool   `json:"allow_ssh"`
-	} `json:"entity"`
+// Space returns the space with the given GUID.
+func (c *Client) Space(ctx context.Context, guid string) (Space, error) {
+	return GetResource[SpaceEntity](ctx, c, "/v2/spaces", guid)
 }
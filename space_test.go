@@ -4,6 +4,8 @@ import (
 	"context"
 	"net/http"
 
+	"github.com/pkg/errors"
+
 	. "github.com/Bo0mer/ccv2"
 
 	. "github.com/onsi/ginkgo"
@@ -45,12 +47,13 @@ var _ = Describe("Spaces", func() {
                 "allow_ssh": true,
                 "name": "rocket",
                 "organization_guid": "d154425c-dccc-42e6-b6b4-27d46c3b42cb",
-                "space_quota_definition_guid": null
+                "space_quota_definition_guid": "a9097bc8-c6cf-4a8f-bc47-623fa22e8019"
             },
             "metadata": {
                 "created_at": "2016-06-08T16:41:40Z",
                 "guid": "2e100106-0b74-4062-8671-0d375f951cb4",
-                "updated_at": "2016-06-08T16:41:26Z"
+                "updated_at": "2016-06-08T16:41:26Z",
+                "url": "/v2/spaces/2e100106-0b74-4062-8671-0d375f951cb4"
             }
         }
     ]
@@ -70,11 +73,12 @@ var _ = Describe("Spaces", func() {
 			Ω(spaces).Should(HaveLen(1))
 			space := spaces[0]
 			Ω(space.GUID).Should(Equal("2e100106-0b74-4062-8671-0d375f951cb4"))
-			Ω(space.CreatedAt).Should(Equal("2016-06-08T16:41:40Z"))
-			Ω(space.UpdatedAt).Should(Equal("2016-06-08T16:41:26Z"))
+			Ω(space.CreatedAt).Should(Equal(parseTime("2016-06-08T16:41:40Z")))
+			Ω(space.UpdatedAt).Should(Equal(parseTime("2016-06-08T16:41:26Z")))
+			Ω(space.URL).Should(Equal("/v2/spaces/2e100106-0b74-4062-8671-0d375f951cb4"))
 			Ω(space.Entity.Name).Should(Equal("rocket"))
 			Ω(space.Entity.OrganizationGUID).Should(Equal("d154425c-dccc-42e6-b6b4-27d46c3b42cb"))
-			Ω(space.Entity.SpaceQuotaDefinitonGUID).Should(Equal(""))
+			Ω(space.Entity.SpaceQuotaDefinitionGUID).Should(Equal("a9097bc8-c6cf-4a8f-bc47-623fa22e8019"))
 			Ω(space.Entity.AllowSSH).Should(Equal(true))
 		})
 
@@ -89,8 +93,64 @@ var _ = Describe("Spaces", func() {
 		})
 
 		It("should have returned a UnexpectedResponseError", func() {
-			Ω(err).Should(Equal(notFoundErr))
+			Ω(err).Should(beNotFoundErr())
+		})
+	})
+
+})
+
+var _ = Describe("Space", func() {
+	var client *Client
+	var server *ghttp.Server
+
+	var space Space
+	var err error
+
+	BeforeEach(func() {
+		client, server = setupTestClientAndServer()
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	JustBeforeEach(func() {
+		space, err = client.Space(context.Background(), "2e100106-0b74-4062-8671-0d375f951cb4")
+	})
+
+	Context("when the server returns a valid response", func() {
+		BeforeEach(func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/v2/spaces/2e100106-0b74-4062-8671-0d375f951cb4"),
+					ghttp.RespondWith(http.StatusOK, `
+{
+    "metadata": {
+        "guid": "2e100106-0b74-4062-8671-0d375f951cb4"
+    },
+    "entity": {
+        "name": "rocket"
+    }
+}`),
+				),
+			)
+		})
+
+		It("should have returned the space", func() {
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(space.GUID).Should(Equal("2e100106-0b74-4062-8671-0d375f951cb4"))
+			Ω(space.Entity.Name).Should(Equal("rocket"))
 		})
 	})
 
+	Context("when the space does not exist", func() {
+		BeforeEach(func() {
+			server.AppendHandlers(notFoundHandler())
+		})
+
+		It("should have returned an error matching ErrNotFound", func() {
+			Ω(err).Should(beNotFoundErr())
+			Ω(errors.Is(err, ErrNotFound)).Should(BeTrue())
+		})
+	})
 })
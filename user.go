@@ -0,0 +1,235 @@
+package ccv2
+
+import (
+	"context"
+	"iter"
+	"net/url"
+
+	"github.com/pkg/errors"
+)
+
+// User represents a Cloud Foundry user.
+type User = Resource[UserEntity]
+
+// UserEntity holds the fields of a user.
+type UserEntity struct {
+	// Username is resolved by the Cloud Controller from UAA and is empty
+	// for users that are not known to UAA, e.g. clients.
+	Username         string `json:"username"`
+	Admin            bool   `json:"admin"`
+	Active           bool   `json:"active"`
+	DefaultSpaceGUID string `json:"default_space_guid"`
+}
+
+// Users list all users that conform to the provided queries.
+func (c *Client) Users(ctx context.Context, queries ...Query) ([]User, error) {
+	return ListResources[UserEntity](ctx, c, "/v2/users", queries...)
+}
+
+// EachUser returns an iterator over all users that conform to the provided
+// queries. Unlike Users, it holds at most one page in memory and stops
+// requesting pages as soon as the loop is exited.
+func (c *Client) EachUser(ctx context.Context, queries ...Query) iter.Seq2[User, error] {
+	return EachResource[UserEntity](ctx, c, "/v2/users", queries...)
+}
+
+// User returns the user with the given GUID.
+func (c *Client) User(ctx context.Context, guid string) (User, error) {
+	return GetResource[UserEntity](ctx, c, "/v2/users", guid)
+}
+
+// Role specifies a role a user holds in an organization or a space.
+type Role string
+
+const (
+	// RoleOrganizationManager is the role of organization managers.
+	RoleOrganizationManager Role = "organization_manager"
+	// RoleOrganizationBillingManager is the role of organization billing
+	// managers.
+	RoleOrganizationBillingManager Role = "organization_billing_manager"
+	// RoleOrganizationAuditor is the role of organization auditors.
+	RoleOrganizationAuditor Role = "organization_auditor"
+	// RoleOrganizationUser is the role of organization members.
+	RoleOrganizationUser Role = "organization_user"
+	// RoleSpaceManager is the role of space managers.
+	RoleSpaceManager Role = "space_manager"
+	// RoleSpaceDeveloper is the role of space developers.
+	RoleSpaceDeveloper Role = "space_developer"
+	// RoleSpaceAuditor is the role of space auditors.
+	RoleSpaceAuditor Role = "space_auditor"
+)
+
+// organizationRoles are the roles a user can hold in an organization.
+var organizationRoles = []Role{
+	RoleOrganizationManager,
+	RoleOrganizationBillingManager,
+	RoleOrganizationAuditor,
+	RoleOrganizationUser,
+}
+
+// spaceRoles are the roles a user can hold in a space.
+var spaceRoles = []Role{
+	RoleSpaceManager,
+	RoleSpaceDeveloper,
+	RoleSpaceAuditor,
+}
+
+// IsSpaceRole reports whether the role is held in a space, as opposed to an
+// organization.
+func (r Role) IsSpaceRole() bool {
+	for _, role := range spaceRoles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
+// roleCollection names the collections related to a role.
+type roleCollection struct {
+	// parent is the collection of organizations or spaces in which the role
+	// is held.
+	parent string
+	// users is the sub-collection of parent listing the users that hold
+	// the role.
+	users string
+	// userParents is the sub-collection of /v2/users listing the
+	// organizations or spaces in which a user holds the role.
+	userParents string
+}
+
+// roleCollections maps each role to its collections.
+var roleCollections = map[Role]roleCollection{
+	RoleOrganizationManager:        {"organizations", "managers", "managed_organizations"},
+	RoleOrganizationBillingManager: {"organizations", "billing_managers", "billing_managed_organizations"},
+	RoleOrganizationAuditor:        {"organizations", "auditors", "audited_organizations"},
+	RoleOrganizationUser:           {"organizations", "users", "organizations"},
+	RoleSpaceManager:               {"spaces", "managers", "managed_spaces"},
+	RoleSpaceDeveloper:             {"spaces", "developers", "spaces"},
+	RoleSpaceAuditor:               {"spaces", "auditors", "audited_spaces"},
+}
+
+// collection returns the path of the collection listing the users that hold
+// the role in the organization or space with the given GUID.
+func (r Role) collection(guid string) (string, error) {
+	rc, ok := roleCollections[r]
+	if !ok {
+		return "", errors.Errorf("unknown role %q", r)
+	}
+	return "/v2/" + rc.parent + "/" + url.PathEscape(guid) + "/" + rc.users, nil
+}
+
+// userCollection returns the path of the collection listing the
+// organizations or spaces in which the user with the given GUID holds the
+// role.
+func (r Role) userCollection(userGUID string) (string, error) {
+	rc, ok := roleCollections[r]
+	if !ok {
+		return "", errors.Errorf("unknown role %q", r)
+	}
+	return "/v2/users/" + url.PathEscape(userGUID) + "/" + rc.userParents, nil
+}
+
+// roleUsers lists the users that hold role in the organization or space with
+// the given GUID.
+func (c *Client) roleUsers(ctx context.Context, role Role, guid string, queries []Query) ([]User, error) {
+	path, err := role.collection(guid)
+	if err != nil {
+		return nil, err
+	}
+	return ListResources[UserEntity](ctx, c, path, queries...)
+}
+
+// OrganizationManagers list all managers of the given organization.
+func (c *Client) OrganizationManagers(ctx context.Context, org Organization, queries ...Query) ([]User, error) {
+	return c.roleUsers(ctx, RoleOrganizationManager, org.GUID, queries)
+}
+
+// OrganizationBillingManagers list all billing managers of the given
+// organization.
+func (c *Client) OrganizationBillingManagers(ctx context.Context, org Organization, queries ...Query) ([]User, error) {
+	return c.roleUsers(ctx, RoleOrganizationBillingManager, org.GUID, queries)
+}
+
+// OrganizationAuditors list all auditors of the given organization.
+func (c *Client) OrganizationAuditors(ctx context.Context, org Organization, queries ...Query) ([]User, error) {
+	return c.roleUsers(ctx, RoleOrganizationAuditor, org.GUID, queries)
+}
+
+// OrganizationUsers list all members of the given organization.
+func (c *Client) OrganizationUsers(ctx context.Context, org Organization, queries ...Query) ([]User, error) {
+	return c.roleUsers(ctx, RoleOrganizationUser, org.GUID, queries)
+}
+
+// SpaceManagers list all managers of the given space.
+func (c *Client) SpaceManagers(ctx context.Context, space Space, queries ...Query) ([]User, error) {
+	return c.roleUsers(ctx, RoleSpaceManager, space.GUID, queries)
+}
+
+// SpaceDevelopers list all developers of the given space.
+func (c *Client) SpaceDevelopers(ctx context.Context, space Space, queries ...Query) ([]User, error) {
+	return c.roleUsers(ctx, RoleSpaceDeveloper, space.GUID, queries)
+}
+
+// SpaceAuditors list all auditors of the given space.
+func (c *Client) SpaceAuditors(ctx context.Context, space Space, queries ...Query) ([]User, error) {
+	return c.roleUsers(ctx, RoleSpaceAuditor, space.GUID, queries)
+}
+
+// RoleAssignment represents a role held by a user.
+type RoleAssignment struct {
+	User User
+	Role Role
+	// Organization is the organization in which the role is held or, for
+	// space roles, the organization of the space.
+	Organization Organization
+	// Space is the space in which the role is held. It is the zero value
+	// for organization roles.
+	Space Space
+}
+
+// RoleMatrix returns the roles held by all users in all organizations and
+// spaces visible to the client. Assignments are grouped by organization,
+// with the organization roles preceding the roles in its spaces.
+//
+// It issues one request per organization and space role, hence it may take
+// a while on large foundations.
+func (c *Client) RoleMatrix(ctx context.Context) ([]RoleAssignment, error) {
+	orgs, err := c.Organizations(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "listing organizations failed")
+	}
+	spaces, err := c.Spaces(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "listing spaces failed")
+	}
+	orgSpaces := make(map[string][]Space)
+	for _, space := range spaces {
+		orgSpaces[space.Entity.OrganizationGUID] = append(orgSpaces[space.Entity.OrganizationGUID], space)
+	}
+
+	var matrix []RoleAssignment
+	for _, org := range orgs {
+		for _, role := range organizationRoles {
+			users, err := c.roleUsers(ctx, role, org.GUID, nil)
+			if err != nil {
+				return nil, errors.Wrapf(err, "listing %s users of organization %s failed", role, org.GUID)
+			}
+			for _, user := range users {
+				matrix = append(matrix, RoleAssignment{User: user, Role: role, Organization: org})
+			}
+		}
+		for _, space := range orgSpaces[org.GUID] {
+			for _, role := range spaceRoles {
+				users, err := c.roleUsers(ctx, role, space.GUID, nil)
+				if err != nil {
+					return nil, errors.Wrapf(err, "listing %s users of space %s failed", role, space.GUID)
+				}
+				for _, user := range users {
+					matrix = append(matrix, RoleAssignment{User: user, Role: role, Organization: org, Space: space})
+				}
+			}
+		}
+	}
+	return matrix, nil
+}
@@ -0,0 +1,212 @@
+package ccv2_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	. "github.com/Bo0mer/ccv2"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("Users", func() {
+	var client *Client
+	var server *ghttp.Server
+
+	var err error
+
+	BeforeEach(func() {
+		client, server = setupTestClientAndServer()
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	Describe("Users", func() {
+		var users []User
+
+		JustBeforeEach(func() {
+			users, err = client.Users(context.Background(), Eq(FilterManagedOrganizationGUID, "d154425c-dccc-42e6-b6b4-27d46c3b42cb"))
+		})
+
+		Context("when the server returns a valid response", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/v2/users", "q=managed_organization_guid%3Ad154425c-dccc-42e6-b6b4-27d46c3b42cb"),
+						ghttp.RespondWith(http.StatusOK, usersResponse("alice")),
+					),
+				)
+			})
+
+			It("should have returned the users", func() {
+				Ω(err).ShouldNot(HaveOccurred())
+				Ω(users).Should(HaveLen(1))
+				Ω(users[0].GUID).Should(Equal("alice-guid"))
+				Ω(users[0].Entity).Should(Equal(UserEntity{
+					Username:         "alice",
+					Active:           true,
+					DefaultSpaceGUID: "1053174d-eb79-4f16-bf82-9f83a52d6e84",
+				}))
+			})
+		})
+
+		Context("when the server returns a non-2XX response", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(notFoundHandler())
+			})
+
+			It("should have returned a UnexpectedResponseError", func() {
+				Ω(err).Should(beNotFoundErr())
+			})
+		})
+	})
+
+	Describe("OrganizationManagers", func() {
+		var users []User
+
+		JustBeforeEach(func() {
+			org := Organization{Metadata: Metadata{GUID: "d154425c-dccc-42e6-b6b4-27d46c3b42cb"}}
+			users, err = client.OrganizationManagers(context.Background(), org)
+		})
+
+		BeforeEach(func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/v2/organizations/d154425c-dccc-42e6-b6b4-27d46c3b42cb/managers"),
+					ghttp.RespondWith(http.StatusOK, usersResponse("alice")),
+				),
+			)
+		})
+
+		It("should have returned the managers of the organization", func() {
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(users).Should(HaveLen(1))
+			Ω(users[0].Entity.Username).Should(Equal("alice"))
+		})
+	})
+
+	Describe("SpaceDevelopers", func() {
+		var users []User
+
+		JustBeforeEach(func() {
+			space := Space{Metadata: Metadata{GUID: "1053174d-eb79-4f16-bf82-9f83a52d6e84"}}
+			users, err = client.SpaceDevelopers(context.Background(), space)
+		})
+
+		BeforeEach(func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/v2/spaces/1053174d-eb79-4f16-bf82-9f83a52d6e84/developers"),
+					ghttp.RespondWith(http.StatusOK, usersResponse("bob")),
+				),
+			)
+		})
+
+		It("should have returned the developers of the space", func() {
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(users).Should(HaveLen(1))
+			Ω(users[0].Entity.Username).Should(Equal("bob"))
+		})
+
+		It("should have validated the queries against the sub-collection", func() {
+			space := Space{Metadata: Metadata{GUID: "1053174d-eb79-4f16-bf82-9f83a52d6e84"}}
+			_, err := client.SpaceDevelopers(context.Background(), space, Eq(FilterName, "bob"))
+			Ω(err).Should(MatchError(ContainSubstring(`filter "name" is not supported by /v2/spaces/1053174d-eb79-4f16-bf82-9f83a52d6e84/developers`)))
+			Ω(server.ReceivedRequests()).Should(HaveLen(1))
+		})
+
+		It("should have escaped the GUID of the space", func() {
+			server.AppendHandlers(ghttp.RespondWith(http.StatusOK, usersResponse()))
+			space := Space{Metadata: Metadata{GUID: "a/b"}}
+			_, err := client.SpaceDevelopers(context.Background(), space)
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(server.ReceivedRequests()).Should(HaveLen(2))
+			Ω(server.ReceivedRequests()[1].RequestURI).Should(Equal("/v2/spaces/a%2Fb/developers"))
+		})
+	})
+
+	Describe("RoleMatrix", func() {
+		var matrix []RoleAssignment
+
+		const orgGUID = "d154425c-dccc-42e6-b6b4-27d46c3b42cb"
+		const spaceGUID = "1053174d-eb79-4f16-bf82-9f83a52d6e84"
+
+		JustBeforeEach(func() {
+			matrix, err = client.RoleMatrix(context.Background())
+		})
+
+		BeforeEach(func() {
+			server.RouteToHandler("GET", "/v2/organizations", ghttp.RespondWith(http.StatusOK, fmt.Sprintf(`
+{
+    "next_url": null,
+    "resources": [
+        {"metadata": {"guid": %q}, "entity": {"name": "acme"}}
+    ]
+}`, orgGUID)))
+			server.RouteToHandler("GET", "/v2/spaces", ghttp.RespondWith(http.StatusOK, fmt.Sprintf(`
+{
+    "next_url": null,
+    "resources": [
+        {"metadata": {"guid": %q}, "entity": {"name": "dev", "organization_guid": %q}}
+    ]
+}`, spaceGUID, orgGUID)))
+			for _, path := range []string{
+				"/v2/organizations/" + orgGUID + "/billing_managers",
+				"/v2/organizations/" + orgGUID + "/auditors",
+				"/v2/spaces/" + spaceGUID + "/managers",
+				"/v2/spaces/" + spaceGUID + "/auditors",
+			} {
+				server.RouteToHandler("GET", path, ghttp.RespondWith(http.StatusOK, usersResponse()))
+			}
+			server.RouteToHandler("GET", "/v2/organizations/"+orgGUID+"/managers", ghttp.RespondWith(http.StatusOK, usersResponse("alice")))
+			server.RouteToHandler("GET", "/v2/organizations/"+orgGUID+"/users", ghttp.RespondWith(http.StatusOK, usersResponse("alice", "bob")))
+			server.RouteToHandler("GET", "/v2/spaces/"+spaceGUID+"/developers", ghttp.RespondWith(http.StatusOK, usersResponse("bob")))
+		})
+
+		It("should have returned the roles of all users", func() {
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(matrix).Should(HaveLen(4))
+
+			type row struct{ user, role, org, space string }
+			rows := make([]row, len(matrix))
+			for i, a := range matrix {
+				rows[i] = row{a.User.Entity.Username, string(a.Role), a.Organization.Entity.Name, a.Space.Entity.Name}
+			}
+			Ω(rows).Should(Equal([]row{
+				{"alice", "organization_manager", "acme", ""},
+				{"alice", "organization_user", "acme", ""},
+				{"bob", "organization_user", "acme", ""},
+				{"bob", "space_developer", "acme", "dev"},
+			}))
+			Ω(matrix[3].Role.IsSpaceRole()).Should(BeTrue())
+			Ω(matrix[0].Role.IsSpaceRole()).Should(BeFalse())
+		})
+	})
+})
+
+func usersResponse(usernames ...string) string {
+	resources := ""
+	for i, name := range usernames {
+		if i > 0 {
+			resources += ","
+		}
+		resources += fmt.Sprintf(`
+        {
+            "metadata": {
+                "guid": "%s-guid"
+            },
+            "entity": {
+                "admin": false,
+                "active": true,
+                "default_space_guid": "1053174d-eb79-4f16-bf82-9f83a52d6e84",
+                "username": %q
+            }
+        }`, name, name)
+	}
+	return fmt.Sprintf(`{"next_url": null, "resources": [%s]}`, resources)
+}